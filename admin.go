@@ -0,0 +1,110 @@
+package freshdesk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// AdminManager covers the account configuration endpoints, which need an
+// administrator's API key for everything but listing.
+type AdminManager interface {
+	TicketFields() (TicketFieldSlice, error)
+	TicketFieldsContext(context.Context) (TicketFieldSlice, error)
+	ViewTicketField(int64) (TicketField, error)
+	ViewTicketFieldContext(context.Context, int64) (TicketField, error)
+	CreateTicketField(CreateTicketField) (TicketField, error)
+	CreateTicketFieldContext(context.Context, CreateTicketField) (TicketField, error)
+	UpdateTicketField(int64, UpdateTicketField) (TicketField, error)
+	UpdateTicketFieldContext(context.Context, int64, UpdateTicketField) (TicketField, error)
+	DeleteTicketField(int64) error
+	DeleteTicketFieldContext(context.Context, int64) error
+}
+
+type adminManager struct {
+	client *ApiClient
+}
+
+func newAdminManager(client *ApiClient) adminManager {
+	return adminManager{
+		client,
+	}
+}
+
+// TicketFields returns the ticket fields of the account, default fields
+// included, ordered by position.
+func (manager adminManager) TicketFields() (TicketFieldSlice, error) {
+	return manager.TicketFieldsContext(context.Background())
+}
+
+func (manager adminManager) TicketFieldsContext(ctx context.Context) (TicketFieldSlice, error) {
+	ctx = withOperation(ctx, "ticket_fields.list")
+	output := TicketFieldSlice{}
+	_, err := manager.client.get(ctx, endpoints.admin.ticketFields.all, &output)
+	if err != nil {
+		return TicketFieldSlice{}, err
+	}
+	sort.Stable(output)
+	return output, nil
+}
+
+// ViewTicketField returns a ticket field with its sections.
+func (manager adminManager) ViewTicketField(id int64) (TicketField, error) {
+	return manager.ViewTicketFieldContext(context.Background(), id)
+}
+
+func (manager adminManager) ViewTicketFieldContext(ctx context.Context, id int64) (TicketField, error) {
+	ctx = withOperation(ctx, "ticket_fields.view")
+	output := TicketField{}
+	_, err := manager.client.get(ctx, endpoints.admin.ticketFields.view(id), &output)
+	if err != nil {
+		return TicketField{}, err
+	}
+	return output, nil
+}
+
+func (manager adminManager) CreateTicketField(field CreateTicketField) (TicketField, error) {
+	return manager.CreateTicketFieldContext(context.Background(), field)
+}
+
+func (manager adminManager) CreateTicketFieldContext(ctx context.Context, field CreateTicketField) (TicketField, error) {
+	ctx = withOperation(ctx, "ticket_fields.create")
+	output := TicketField{}
+	jsonb, err := json.Marshal(field)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.postJSON(ctx, endpoints.admin.ticketFields.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return TicketField{}, err
+	}
+	return output, nil
+}
+
+func (manager adminManager) UpdateTicketField(id int64, field UpdateTicketField) (TicketField, error) {
+	return manager.UpdateTicketFieldContext(context.Background(), id, field)
+}
+
+func (manager adminManager) UpdateTicketFieldContext(ctx context.Context, id int64, field UpdateTicketField) (TicketField, error) {
+	ctx = withOperation(ctx, "ticket_fields.update")
+	output := TicketField{}
+	jsonb, err := json.Marshal(field)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.put(ctx, endpoints.admin.ticketFields.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return TicketField{}, err
+	}
+	return output, nil
+}
+
+func (manager adminManager) DeleteTicketField(id int64) error {
+	return manager.DeleteTicketFieldContext(context.Background(), id)
+}
+
+func (manager adminManager) DeleteTicketFieldContext(ctx context.Context, id int64) error {
+	ctx = withOperation(ctx, "ticket_fields.delete")
+	return manager.client.delete(ctx, endpoints.admin.ticketFields.delete(id), http.StatusNoContent)
+}
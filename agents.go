@@ -1,13 +1,17 @@
 package freshdesk
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 type AgentManager interface {
 	All() (AgentSlice, error)
+	AllContext(context.Context) (AgentSlice, error)
+	List(*ListOptions) *Iterator[Agent]
 	Me() (Agent, error)
+	MeContext(context.Context) (Agent, error)
 }
 
 type agentManager struct {
@@ -49,29 +53,29 @@ func (s AgentSlice) Print() {
 }
 
 func (manager agentManager) All() (AgentSlice, error) {
-	output := AgentSlice{}
-	headers, err := manager.client.get(endpoints.agents.all, &output)
+	return manager.AllContext(context.Background())
+}
+
+func (manager agentManager) AllContext(ctx context.Context) (AgentSlice, error) {
+	output, err := manager.List(nil).All(ctx)
 	if err != nil {
 		return AgentSlice{}, err
 	}
-	for {
-		nextLink := manager.client.getNextLink(headers)
-		if nextLink == "" {
-			break
-		}
-		nextSlice := AgentSlice{}
-		headers, err = manager.client.get(nextLink, &nextSlice)
-		if err != nil {
-			return AgentSlice{}, err
-		}
-		output = append(output, nextSlice...)
-	}
 	return output, nil
 }
 
+func (manager agentManager) List(options *ListOptions) *Iterator[Agent] {
+	return newLinkIterator[Agent](manager.client, endpoints.agents.all, options, "agents.list", nil)
+}
+
 func (manager agentManager) Me() (Agent, error) {
+	return manager.MeContext(context.Background())
+}
+
+func (manager agentManager) MeContext(ctx context.Context) (Agent, error) {
+	ctx = withOperation(ctx, "agents.me")
 	output := Agent{}
-	_, err := manager.client.get(endpoints.agents.me, &output)
+	_, err := manager.client.get(ctx, endpoints.agents.me, &output)
 	if err != nil {
 		return Agent{}, err
 	}
@@ -0,0 +1,181 @@
+package freshdesk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const jsonContentType = "application/json"
+
+type AttachmentManager interface {
+	Download(Attachment) (io.ReadCloser, error)
+	DownloadContext(context.Context, Attachment) (io.ReadCloser, error)
+	Delete(int64) error
+	DeleteContext(context.Context, int64) error
+}
+
+type attachmentManager struct {
+	client *ApiClient
+}
+
+func newAttachmentManager(client *ApiClient) attachmentManager {
+	return attachmentManager{
+		client,
+	}
+}
+
+type Attachment struct {
+	ID            int64      `bson:"id" json:"id"`
+	Name          string     `bson:"name" json:"name"`
+	ContentType   string     `bson:"content_type" json:"content_type"`
+	Size          int64      `bson:"size" json:"size"`
+	AttachmentURL string     `bson:"attachment_url" json:"attachment_url"`
+	CreatedAt     *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt     *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// File is a file to attach to a new ticket, reply or note. Requests with
+// files are sent as multipart/form-data, which Freshdesk limits to 20MB.
+type File struct {
+	Name string
+	// ContentType defaults to the type of Name's extension.
+	ContentType string
+	Content     io.Reader
+}
+
+func (a Attachment) Print() {
+	fmt.Printf("%s (%s, %d bytes)\n", a.Name, a.ContentType, a.Size)
+}
+
+func (manager attachmentManager) Download(attachment Attachment) (io.ReadCloser, error) {
+	return manager.DownloadContext(context.Background(), attachment)
+}
+
+// DownloadContext streams the content of an attachment. The caller must
+// close it. Downloads are not subject to the client's Timeout; use ctx to
+// bound them.
+func (manager attachmentManager) DownloadContext(ctx context.Context, attachment Attachment) (io.ReadCloser, error) {
+	ctx = withOperation(ctx, "attachments.download")
+	u, err := url.Parse(attachment.AttachmentURL)
+	if err != nil {
+		return nil, err
+	}
+	// Attachment URLs are pre-signed, usually on another host, so the API
+	// key is not sent. Only the path is logged; the query holds the
+	// signature.
+	res, err := manager.client.roundTrip(ctx, manager.client.download, http.MethodGet, attachment.AttachmentURL, u.Path, nil, "", false)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, manager.client.apiError(res, http.StatusOK)
+	}
+	return res.Body, nil
+}
+
+func (manager attachmentManager) Delete(id int64) error {
+	return manager.DeleteContext(context.Background(), id)
+}
+
+func (manager attachmentManager) DeleteContext(ctx context.Context, id int64) error {
+	ctx = withOperation(ctx, "attachments.delete")
+	return manager.client.delete(ctx, endpoints.attachments.delete(id), http.StatusNoContent)
+}
+
+// encodeBody encodes payload as JSON, or as multipart/form-data when files
+// are attached. The files are read into memory so that the request can be
+// retried.
+func encodeBody(payload interface{}, files []File) ([]byte, string, error) {
+	jsonb, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(files) == 0 {
+		return jsonb, jsonContentType, nil
+	}
+
+	fields := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonb))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, "", err
+	}
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, key := range sortedKeys(fields) {
+		if err := writeFormField(writer, key, fields[key]); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, file := range files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(file.Name))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="attachments[]"; filename="%s"`, quoteEscaper.Replace(file.Name)))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return nil, "", fmt.Errorf("reading attachment %s: %w", file.Name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// writeFormField writes a JSON value with the bracket notation Freshdesk
+// expects: tags[] for arrays and custom_fields[name] for objects.
+func writeFormField(writer *multipart.Writer, key string, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		for _, inner := range sortedKeys(v) {
+			if err := writeFormField(writer, key+"["+inner+"]", v[inner]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		for _, inner := range v {
+			if err := writeFormField(writer, key+"[]", inner); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return writer.WriteField(key, fmt.Sprint(value))
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,204 @@
+package freshdesk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func fastRetries() *freshdesk.RetryPolicy {
+	policy := freshdesk.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	return policy
+}
+
+func TestCreateTicketWithAttachmentsIsMultipart(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	client := server.Client(nil)
+
+	ticket, err := client.Tickets.Create(freshdesk.CreateTicket{
+		Email:        "ada@example.com",
+		Subject:      "Broken printer",
+		Description:  "See the logs",
+		Status:       2,
+		Priority:     1,
+		Tags:         []string{"printer", "urgent"},
+		CustomFields: map[string]interface{}{"cf_floor": 3},
+		Attachments: []freshdesk.File{
+			{Name: "printer.log", Content: strings.NewReader("paper jam")},
+			{Name: "photo", ContentType: "image/png", Content: bytes.NewReader([]byte{0x89, 'P', 'N', 'G'})},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ticket.Attachments) != 2 {
+		t.Fatalf("got %d attachments, want 2", len(ticket.Attachments))
+	}
+
+	requests := server.Requests()
+	body := requests[len(requests)-1].Body
+	boundary := strings.TrimPrefix(strings.SplitN(string(body), "\r\n", 2)[0], "--")
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	fields := map[string][]string{}
+	files := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(part)
+		if part.FileName() != "" {
+			if part.FormName() != "attachments[]" {
+				t.Errorf("file %s sent as %s, want attachments[]", part.FileName(), part.FormName())
+			}
+			files[part.FileName()] = part.Header.Get("Content-Type")
+			continue
+		}
+		fields[part.FormName()] = append(fields[part.FormName()], string(content))
+	}
+
+	if got := fields["tags[]"]; len(got) != 2 || got[0] != "printer" || got[1] != "urgent" {
+		t.Errorf("tags[] = %v, want [printer urgent]", got)
+	}
+	if got := fields["custom_fields[cf_floor]"]; len(got) != 1 || got[0] != "3" {
+		t.Errorf("custom_fields[cf_floor] = %v, want [3]", got)
+	}
+	if got := fields["status"]; len(got) != 1 || got[0] != "2" {
+		t.Errorf("status = %v, want [2]", got)
+	}
+	if files["printer.log"] == "" || files["photo"] != "image/png" {
+		t.Errorf("file content types = %v", files)
+	}
+}
+
+func TestCreateTicketWithoutAttachmentsIsJSON(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	client := server.Client(nil)
+
+	_, err := client.Tickets.Create(freshdesk.CreateTicket{Email: "ada@example.com", Subject: "Hi", Description: "Hello", Status: 2, Priority: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := server.Requests()
+	if body := requests[len(requests)-1].Body; !bytes.HasPrefix(body, []byte("{")) {
+		t.Errorf("body = %q, want JSON", body)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+
+	authorization := map[string]string{}
+	client := server.Client(&freshdesk.ClientOptions{
+		RetryPolicy: fastRetries(),
+		Middleware: []freshdesk.Middleware{func(next freshdesk.RoundTripFunc) freshdesk.RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				authorization[req.URL.Path] = req.Header.Get("Authorization")
+				return next(req)
+			}
+		}},
+	})
+	ticket, err := client.Tickets.Create(freshdesk.CreateTicket{
+		Email: "ada@example.com", Subject: "Logs", Description: "Attached", Status: 2, Priority: 1,
+		Attachments: []freshdesk.File{{Name: "server.log", Content: strings.NewReader("disk full")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachment := ticket.Attachments[0]
+
+	// The first attempt fails and is retried like any other GET.
+	server.InjectFault(freshdesktest.Fault{Path: "/attachments/", Status: http.StatusServiceUnavailable, Count: 1})
+	content, err := client.Attachments.Download(attachment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "disk full" {
+		t.Errorf("content = %q, want %q", data, "disk full")
+	}
+
+	downloads := 0
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodGet && strings.HasPrefix(req.Path, "/attachments/") {
+			downloads++
+		}
+	}
+	if downloads != 2 {
+		t.Errorf("got %d download attempts, want 2", downloads)
+	}
+	for path, header := range authorization {
+		if strings.HasPrefix(path, "/attachments/") && header != "" {
+			t.Errorf("Authorization sent to the attachment URL %s", path)
+		}
+		if path == "/api/v2/tickets" && header == "" {
+			t.Errorf("Authorization missing from %s", path)
+		}
+	}
+}
+
+func TestDownloadAttachmentIsNotCutByTimeout(t *testing.T) {
+	// The body arrives after the client's Timeout, which only bounds API
+	// calls.
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first half, "))
+		w.(http.Flusher).Flush()
+		time.Sleep(150 * time.Millisecond)
+		w.Write([]byte("second half"))
+	}))
+	defer slow.Close()
+	client := freshdesk.Init("fake", "key", &freshdesk.ClientOptions{BaseURL: slow.URL, Timeout: 50 * time.Millisecond})
+
+	content, err := client.Attachments.Download(freshdesk.Attachment{AttachmentURL: slow.URL + "/file.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "first half, second half" {
+		t.Errorf("content = %q", data)
+	}
+
+	// The context still bounds the download.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	content, err = client.Attachments.DownloadContext(ctx, freshdesk.Attachment{AttachmentURL: slow.URL + "/file.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer content.Close()
+	if _, err := io.ReadAll(content); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDownloadAttachmentInvalidURL(t *testing.T) {
+	client := freshdesk.Init("fake", "key", nil)
+	if _, err := client.Attachments.Download(freshdesk.Attachment{AttachmentURL: "://missing-scheme"}); err == nil {
+		t.Error("expected an error for an invalid attachment URL")
+	}
+}
@@ -1,18 +1,31 @@
 package freshdesk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
 	"github.com/nextlinktechnology/mgm/v3"
 )
 
 type CompanyManager interface {
 	All() (CompanySlice, error)
+	AllContext(context.Context) (CompanySlice, error)
+	List(*ListOptions) *Iterator[Company]
 	Create(CreateCompany) (Company, error)
+	CreateContext(context.Context, CreateCompany) (Company, error)
 	Update(int64, CreateCompany) (Company, error)
+	UpdateContext(context.Context, int64, CreateCompany) (Company, error)
+	View(int64) (Company, error)
+	ViewContext(context.Context, int64) (Company, error)
+	Search(querybuilder.Query) (CompanyResults, error)
+	SearchContext(context.Context, querybuilder.Query) (CompanyResults, error)
+	SearchIter(querybuilder.Query) *Iterator[Company]
+	Autocomplete(string) (CompanySlice, error)
+	AutocompleteContext(context.Context, string) (CompanySlice, error)
 }
 
 type companyManager struct {
@@ -25,6 +38,14 @@ func newCompanyManager(client *ApiClient) companyManager {
 	}
 }
 
+// CompanyResults holds the companies of one search page. Total counts every
+// match; companies past the first 300 cannot be reached, so narrow the query
+// when Truncated is set.
+type CompanyResults struct {
+	searchResults[Company]
+	Results CompanySlice
+}
+
 type Company struct {
 	mgm.DefaultModel `bson:",inline"`
 	ID               int64                  `bson:"id" json:"id"`
@@ -74,33 +95,33 @@ func (c CompanySlice) Print() {
 }
 
 func (manager companyManager) All() (CompanySlice, error) {
-	output := CompanySlice{}
-	headers, err := manager.client.get(endpoints.companies.all, &output)
+	return manager.AllContext(context.Background())
+}
+
+func (manager companyManager) AllContext(ctx context.Context) (CompanySlice, error) {
+	output, err := manager.List(nil).All(ctx)
 	if err != nil {
 		return CompanySlice{}, err
 	}
-	for {
-		nextLink := manager.client.getNextLink(headers)
-		if nextLink == "" {
-			break
-		}
-		nextSlice := CompanySlice{}
-		headers, err = manager.client.get(nextLink, &nextSlice)
-		if err != nil {
-			return CompanySlice{}, err
-		}
-		output = append(output, nextSlice...)
-	}
 	return output, nil
 }
 
+func (manager companyManager) List(options *ListOptions) *Iterator[Company] {
+	return newLinkIterator[Company](manager.client, endpoints.companies.all, options, "companies.list", nil)
+}
+
 func (manager companyManager) Create(company CreateCompany) (Company, error) {
+	return manager.CreateContext(context.Background(), company)
+}
+
+func (manager companyManager) CreateContext(ctx context.Context, company CreateCompany) (Company, error) {
+	ctx = withOperation(ctx, "companies.create")
 	output := Company{}
 	jsonb, err := json.Marshal(company)
 	if err != nil {
 		return output, err
 	}
-	err = manager.client.postJSON(endpoints.companies.create, jsonb, &output, http.StatusCreated)
+	err = manager.client.postJSON(ctx, endpoints.companies.create, jsonb, &output, http.StatusCreated)
 	if err != nil {
 		return Company{}, err
 	}
@@ -108,14 +129,90 @@ func (manager companyManager) Create(company CreateCompany) (Company, error) {
 }
 
 func (manager companyManager) Update(id int64, company CreateCompany) (Company, error) {
+	return manager.UpdateContext(context.Background(), id, company)
+}
+
+func (manager companyManager) UpdateContext(ctx context.Context, id int64, company CreateCompany) (Company, error) {
+	ctx = withOperation(ctx, "companies.update")
 	output := Company{}
 	jsonb, err := json.Marshal(company)
 	if err != nil {
 		return output, err
 	}
-	err = manager.client.put(endpoints.companies.update(id), jsonb, &output, http.StatusOK)
+	err = manager.client.put(ctx, endpoints.companies.update(id), jsonb, &output, http.StatusOK)
 	if err != nil {
 		return Company{}, err
 	}
 	return output, nil
 }
+
+func (manager companyManager) View(id int64) (Company, error) {
+	return manager.ViewContext(context.Background(), id)
+}
+
+func (manager companyManager) ViewContext(ctx context.Context, id int64) (Company, error) {
+	ctx = withOperation(ctx, "companies.view")
+	output := Company{}
+	_, err := manager.client.get(ctx, endpoints.companies.view(id), &output)
+	if err != nil {
+		return Company{}, err
+	}
+	return output, nil
+}
+
+func (manager companyManager) Search(query querybuilder.Query) (CompanyResults, error) {
+	return manager.SearchContext(context.Background(), query)
+}
+
+// SearchContext returns the first page of companies matching query. Use Next
+// for the following pages.
+func (manager companyManager) SearchContext(ctx context.Context, query querybuilder.Query) (CompanyResults, error) {
+	results := CompanyResults{
+		searchResults: newSearchResults[Company](manager.client, "companies", endpoints.companies.search(query.URLSafe())),
+	}
+	return results.NextContext(ctx)
+}
+
+// SearchIter returns an Iterator over the companies matching query, for use
+// with ForEach or All instead of calling Next page by page.
+func (manager companyManager) SearchIter(query querybuilder.Query) *Iterator[Company] {
+	return newSearchIterator[Company](manager.client, "companies", endpoints.companies.search(query.URLSafe()), nil)
+}
+
+func (manager companyManager) Autocomplete(name string) (CompanySlice, error) {
+	return manager.AutocompleteContext(context.Background(), name)
+}
+
+// AutocompleteContext returns the companies whose name starts with name.
+// Only their ID and Name are set.
+func (manager companyManager) AutocompleteContext(ctx context.Context, name string) (CompanySlice, error) {
+	ctx = withOperation(ctx, "companies.autocomplete")
+	output := struct {
+		Companies CompanySlice `json:"companies"`
+	}{}
+	_, err := manager.client.get(ctx, endpoints.companies.autocomplete(name), &output)
+	if err != nil {
+		return CompanySlice{}, err
+	}
+	if output.Companies == nil {
+		return CompanySlice{}, nil
+	}
+	return output.Companies, nil
+}
+
+// Next returns the page of companies after results, or an error when the
+// last page Freshdesk serves has been returned.
+func (results CompanyResults) Next() (CompanyResults, error) {
+	return results.NextContext(context.Background())
+}
+
+func (results CompanyResults) NextContext(ctx context.Context) (CompanyResults, error) {
+	output, next, err := results.next(ctx)
+	if err != nil {
+		return CompanyResults{}, err
+	}
+	return CompanyResults{
+		searchResults: next,
+		Results:       output,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package freshdesk_test
+
+import (
+	"fmt"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
+)
+
+func TestCompanySearchPages(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	for i := 0; i < 45; i++ {
+		server.AddCompany(freshdesk.Company{Name: fmt.Sprintf("Company %d", i), Industry: "Retail"})
+	}
+	server.AddCompany(freshdesk.Company{Name: "Bank", Industry: "Finance"})
+	client := server.Client(nil)
+
+	results, err := client.Companies.Search(querybuilder.Parameter("industry").Is("Retail"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 30 || results.Total != 45 || results.Truncated {
+		t.Fatalf("first page has %d results, Total = %d, Truncated = %v", len(results.Results), results.Total, results.Truncated)
+	}
+	results, err = results.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 15 {
+		t.Errorf("second page has %d results, want 15", len(results.Results))
+	}
+	if _, err := results.Next(); err == nil {
+		t.Error("Next after the last page returned no error")
+	}
+}
+
+func TestCompanyAutocomplete(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddCompany(freshdesk.Company{Name: "Acme"})
+	server.AddCompany(freshdesk.Company{Name: "Acme Labs"})
+	server.AddCompany(freshdesk.Company{Name: "Globex"})
+	client := server.Client(nil)
+
+	companies, err := client.Companies.Autocomplete("acm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(companies) != 2 {
+		t.Errorf("got %d companies, want 2", len(companies))
+	}
+}
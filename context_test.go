@@ -0,0 +1,47 @@
+package freshdesk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestCancelledContext(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Never fetched", Status: 2})
+	server.AddGroup(freshdesk.Group{Name: "Support"})
+	client := server.Client(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := map[string]func() error{
+		"Tickets.ViewContext": func() error {
+			_, err := client.Tickets.ViewContext(ctx, ticket.ID)
+			return err
+		},
+		"Groups.AllContext": func() error {
+			_, err := client.Groups.AllContext(ctx)
+			return err
+		},
+		"Tickets.UpdateContext": func() error {
+			_, err := client.Tickets.UpdateContext(ctx, ticket.ID, freshdesk.UpdateTicket{Priority: freshdesk.Ptr(3)})
+			return err
+		},
+		"Iterator.Next": func() error {
+			_, err := client.Tickets.List(nil).Next(ctx)
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: err = %v, want %v", name, err, context.Canceled)
+		}
+	}
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("the server received %d requests", len(requests))
+	}
+}
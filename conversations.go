@@ -0,0 +1,63 @@
+package freshdesk
+
+import (
+	"context"
+	"net/http"
+)
+
+type ConversationManager interface {
+	List(int64, *ListOptions) *Iterator[Conversation]
+	Update(int64, UpdateConversation) (Conversation, error)
+	UpdateContext(context.Context, int64, UpdateConversation) (Conversation, error)
+	Delete(int64) error
+	DeleteContext(context.Context, int64) error
+}
+
+type conversationManager struct {
+	client *ApiClient
+}
+
+func newConversationManager(client *ApiClient) conversationManager {
+	return conversationManager{
+		client,
+	}
+}
+
+// UpdateConversation changes a note. Freshdesk does not allow editing
+// replies.
+type UpdateConversation struct {
+	Body        string `json:"body,omitempty"`
+	Attachments []File `json:"-"`
+}
+
+// List streams the conversations of a ticket.
+func (manager conversationManager) List(ticketID int64, options *ListOptions) *Iterator[Conversation] {
+	return newLinkIterator[Conversation](manager.client, endpoints.tickets.conversations(ticketID), options, "tickets.conversations.list", nil)
+}
+
+func (manager conversationManager) Update(id int64, conversation UpdateConversation) (Conversation, error) {
+	return manager.UpdateContext(context.Background(), id, conversation)
+}
+
+func (manager conversationManager) UpdateContext(ctx context.Context, id int64, conversation UpdateConversation) (Conversation, error) {
+	ctx = withOperation(ctx, "conversations.update")
+	output := Conversation{}
+	body, contentType, err := encodeBody(conversation, conversation.Attachments)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.sendBody(ctx, http.MethodPut, endpoints.conversations.update(id), body, contentType, &output, http.StatusOK)
+	if err != nil {
+		return Conversation{}, err
+	}
+	return output, nil
+}
+
+func (manager conversationManager) Delete(id int64) error {
+	return manager.DeleteContext(context.Background(), id)
+}
+
+func (manager conversationManager) DeleteContext(ctx context.Context, id int64) error {
+	ctx = withOperation(ctx, "conversations.delete")
+	return manager.client.delete(ctx, endpoints.conversations.delete(id), http.StatusNoContent)
+}
@@ -0,0 +1,81 @@
+package freshdesk_test
+
+import (
+	"strings"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestAddNote(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Audit", Status: 2})
+	client := server.Client(nil)
+
+	private, err := client.Tickets.AddNote(ticket.ID, freshdesk.CreateNote{Body: "<b>Checked</b> by bot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !private.Private || private.TicketID != ticket.ID || private.BodyText != "Checked by bot" {
+		t.Errorf("note = %+v, want a private note on the ticket", private)
+	}
+	requests := server.Requests()
+	if body := string(requests[len(requests)-1].Body); strings.Contains(body, "private") {
+		t.Errorf("sent %s, want Freshdesk's default for private", body)
+	}
+
+	public, err := client.Tickets.AddNote(ticket.ID, freshdesk.CreateNote{
+		Body:         "Visible to the requester",
+		Private:      freshdesk.Ptr(false),
+		NotifyEmails: []string{"agent@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public.Private || len(public.ToEmails) != 1 || public.ToEmails[0] != "agent@example.com" {
+		t.Errorf("note = %+v, want a public note notifying agent@example.com", public)
+	}
+	if notes := server.Conversations(ticket.ID); len(notes) != 2 {
+		t.Errorf("ticket has %d conversations, want 2", len(notes))
+	}
+}
+
+func TestUpdateAndDeleteConversation(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Thread", Status: 2})
+	reply := server.AddConversation(ticket.ID, freshdesk.Conversation{Body: "Hello", Source: 0})
+	client := server.Client(nil)
+	note, err := client.Tickets.AddNote(ticket.ID, freshdesk.CreateNote{Body: "Draft"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := client.Conversations.Update(note.ID, freshdesk.UpdateConversation{Body: "<p>Final</p>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ID != note.ID || updated.Body != "<p>Final</p>" || updated.BodyText != "Final" || !updated.Private {
+		t.Errorf("updated note = %+v", updated)
+	}
+	// Freshdesk only allows editing notes.
+	if _, err := client.Conversations.Update(reply.ID, freshdesk.UpdateConversation{Body: "Edited"}); err == nil {
+		t.Error("a reply was edited")
+	}
+
+	if err := client.Conversations.Delete(note.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Conversations.Delete(note.ID); !freshdesk.IsNotFound(err) {
+		t.Errorf("second Delete: err = %v, want not found", err)
+	}
+	if _, err := client.Conversations.Update(note.ID, freshdesk.UpdateConversation{Body: "Gone"}); !freshdesk.IsNotFound(err) {
+		t.Errorf("Update of a deleted note: err = %v, want not found", err)
+	}
+	remaining := server.Conversations(ticket.ID)
+	if len(remaining) != 1 || remaining[0].ID != reply.ID {
+		t.Errorf("remaining conversations = %+v, want the reply", remaining)
+	}
+}
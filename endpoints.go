@@ -1,11 +1,22 @@
 package freshdesk
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
+
+type adminEndpoints struct {
+	ticketFields ticketFieldEndpoints
+}
 
 type agentEndpoints struct {
 	all string
 	me  string
 }
+type attachmentEndpoints struct {
+	delete func(int64) string
+}
+
 type articleEndpoints struct {
 	delete func(int64) string
 	get    func(int64) string
@@ -16,9 +27,12 @@ type categoryEndpoints struct {
 }
 
 type companyEndpoints struct {
-	all    string
-	create string
-	update func(int64) string
+	all          string
+	create       string
+	view         func(int64) string
+	update       func(int64) string
+	search       func(string) string
+	autocomplete func(string) string
 }
 
 type contactEndpoints struct {
@@ -28,6 +42,11 @@ type contactEndpoints struct {
 	search func(string) string
 }
 
+type conversationEndpoints struct {
+	update func(int64) string
+	delete func(int64) string
+}
+
 type folderEndpoints struct {
 	articles func(int64) string
 }
@@ -36,6 +55,10 @@ type groupEndpoints struct {
 	all string
 }
 
+type jobEndpoints struct {
+	view func(string) string
+}
+
 type slaPolicyEndpoints struct {
 	all    string
 	update func(int64) string
@@ -48,33 +71,89 @@ type solutionEndpoints struct {
 	articles   articleEndpoints
 }
 
+type ticketFieldEndpoints struct {
+	all    string
+	create string
+	view   func(int64) string
+	update func(int64) string
+	delete func(int64) string
+}
+
+type timeEntryEndpoints struct {
+	all         string
+	update      func(int64) string
+	toggleTimer func(int64) string
+	delete      func(int64) string
+}
+
+type surveyEndpoints struct {
+	all                 string
+	satisfactionRatings string
+}
+
 type ticketEndpoints struct {
-	all             string
-	create          string
-	view            func(int64) string
-	search          func(string) string
-	reply           func(int64) string
-	conversations   func(int64) string
-	updatedSinceAll func(string) string
+	all                 string
+	create              string
+	view                func(int64) string
+	update              func(int64) string
+	delete              func(int64) string
+	restore             func(int64) string
+	bulkUpdate          string
+	bulkDelete          string
+	merge               string
+	forward             func(int64) string
+	outboundEmail       string
+	search              func(string) string
+	reply               func(int64) string
+	notes               func(int64) string
+	timeEntries         func(int64) string
+	satisfactionRatings func(int64) string
+	conversations       func(int64) string
+	updatedSinceAll     func(string) string
 }
 
 var endpoints = struct {
-	agents      agentEndpoints
-	companies   companyEndpoints
-	contacts    contactEndpoints
-	groups      groupEndpoints
-	slaPolicies slaPolicyEndpoints
-	solutions   solutionEndpoints
-	tickets     ticketEndpoints
+	admin         adminEndpoints
+	agents        agentEndpoints
+	attachments   attachmentEndpoints
+	companies     companyEndpoints
+	contacts      contactEndpoints
+	conversations conversationEndpoints
+	groups        groupEndpoints
+	jobs          jobEndpoints
+	slaPolicies   slaPolicyEndpoints
+	solutions     solutionEndpoints
+	surveys       surveyEndpoints
+	tickets       ticketEndpoints
+	timeEntries   timeEntryEndpoints
 }{
+	admin: adminEndpoints{
+		ticketFields: ticketFieldEndpoints{
+			all:    "/api/v2/ticket_fields",
+			create: "/api/v2/admin/ticket_fields",
+			view: func(id int64) string {
+				return fmt.Sprintf("/api/v2/admin/ticket_fields/%d?include=section", id)
+			},
+			update: func(id int64) string { return fmt.Sprintf("/api/v2/admin/ticket_fields/%d", id) },
+			delete: func(id int64) string { return fmt.Sprintf("/api/v2/admin/ticket_fields/%d", id) },
+		},
+	},
 	agents: agentEndpoints{
 		all: "/api/v2/agents",
 		me:  "/api/v2/agents/me",
 	},
+	attachments: attachmentEndpoints{
+		delete: func(id int64) string { return fmt.Sprintf("/api/v2/attachments/%d", id) },
+	},
 	companies: companyEndpoints{
 		all:    "/api/v2/companies",
 		create: "/api/v2/companies",
+		view:   func(id int64) string { return fmt.Sprintf("/api/v2/companies/%d", id) },
 		update: func(id int64) string { return fmt.Sprintf("/api/v2/companies/%d", id) },
+		search: func(query string) string { return fmt.Sprintf("/api/v2/search/companies?%s", query) },
+		autocomplete: func(name string) string {
+			return fmt.Sprintf("/api/v2/companies/autocomplete?name=%s", url.QueryEscape(name))
+		},
 	},
 	contacts: contactEndpoints{
 		all:    "/api/v2/contacts",
@@ -82,9 +161,16 @@ var endpoints = struct {
 		update: func(id int64) string { return fmt.Sprintf("/api/v2/contacts/%d", id) },
 		search: func(query string) string { return fmt.Sprintf("/api/v2/search/contacts?%s", query) },
 	},
+	conversations: conversationEndpoints{
+		update: func(id int64) string { return fmt.Sprintf("/api/v2/conversations/%d", id) },
+		delete: func(id int64) string { return fmt.Sprintf("/api/v2/conversations/%d", id) },
+	},
 	groups: groupEndpoints{
 		all: "/api/v2/groups",
 	},
+	jobs: jobEndpoints{
+		view: func(id string) string { return fmt.Sprintf("/api/v2/jobs/%s", url.PathEscape(id)) },
+	},
 	slaPolicies: slaPolicyEndpoints{
 		all:    "/api/v2/sla_policies",
 		update: func(id int64) string { return fmt.Sprintf("/api/v2/sla_policies/%d", id) },
@@ -102,15 +188,38 @@ var endpoints = struct {
 			get:    func(id int64) string { return fmt.Sprintf("/api/v2/solutions/articles/%d", id) }, // Not currently in use
 		},
 	},
+	surveys: surveyEndpoints{
+		all:                 "/api/v2/surveys",
+		satisfactionRatings: "/api/v2/surveys/satisfaction_ratings",
+	},
 	tickets: ticketEndpoints{
 		all:           "/api/v2/tickets",
 		create:        "/api/v2/tickets",
 		view:          func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d", id) },
+		update:        func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d", id) },
+		delete:        func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d", id) },
+		restore:       func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/restore", id) },
+		bulkUpdate:    "/api/v2/tickets/bulk_update",
+		bulkDelete:    "/api/v2/tickets/bulk_delete",
+		merge:         "/api/v2/tickets/merge",
+		forward:       func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/forward", id) },
+		outboundEmail: "/api/v2/tickets/outbound_email",
 		search:        func(query string) string { return fmt.Sprintf("/api/v2/search/tickets?%s", query) },
 		reply:         func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/reply", id) },
+		notes:         func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/notes", id) },
+		timeEntries:   func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/time_entries", id) },
+		satisfactionRatings: func(id int64) string {
+			return fmt.Sprintf("/api/v2/tickets/%d/satisfaction_ratings", id)
+		},
 		conversations: func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/conversations", id) },
 		updatedSinceAll: func(timeString string) string {
 			return fmt.Sprintf("/api/v2/tickets?updated_since=%s", timeString)
 		},
 	},
+	timeEntries: timeEntryEndpoints{
+		all:         "/api/v2/time_entries",
+		update:      func(id int64) string { return fmt.Sprintf("/api/v2/time_entries/%d", id) },
+		toggleTimer: func(id int64) string { return fmt.Sprintf("/api/v2/time_entries/%d/toggle_timer", id) },
+		delete:      func(id int64) string { return fmt.Sprintf("/api/v2/time_entries/%d", id) },
+	},
 }
@@ -1,6 +1,126 @@
 package freshdesk
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors an APIError matches with errors.Is, depending on its status code.
+var (
+	ErrNotFound     = errors.New("freshdesk: not found")
+	ErrUnauthorized = errors.New("freshdesk: unauthorized")
+	ErrForbidden    = errors.New("freshdesk: forbidden")
+	ErrRateLimited  = errors.New("freshdesk: rate limited")
+	ErrValidation   = errors.New("freshdesk: validation failed")
+	// ErrCustomField matches validation failures on custom fields.
+	ErrCustomField = errors.New("freshdesk: custom field validation failed")
+)
+
+// FieldError is a single entry of the "errors" array Freshdesk returns with
+// validation failures.
+type FieldError struct {
+	Field          string `json:"field"`
+	Message        string `json:"message"`
+	Code           string `json:"code"`
+	AdditionalInfo string `json:"additional_info,omitempty"`
+}
+
+// APIError is returned for every response with an unexpected status code.
 type APIError struct {
-	error
+	StatusCode     int
+	ExpectedStatus int
+	Method         string
+	Path           string
+	Description    string
+	Errors         []FieldError
+	RequestID      string
+	// RetryAfter is the delay requested by a 429 response.
+	RetryAfter time.Duration
+	// APIError is the response body, indented when it is JSON.
 	APIError string
 }
+
+func (e APIError) Error() string {
+	var b strings.Builder
+	if e.Method != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	}
+	fmt.Fprintf(&b, "received status code %d (%d expected)", e.StatusCode, e.ExpectedStatus)
+	if e.Description != "" {
+		fmt.Fprintf(&b, ": %s", e.Description)
+	}
+	for _, fieldError := range e.Errors {
+		fmt.Fprintf(&b, "; %s", fieldError.Error())
+	}
+	return b.String()
+}
+
+// Is reports whether the error matches one of the package's sentinel errors.
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || len(e.Errors) > 0
+	case ErrCustomField:
+		return len(e.CustomFieldErrors()) > 0
+	}
+	return false
+}
+
+// CustomFieldErrors returns the field errors reported on custom fields.
+func (e APIError) CustomFieldErrors() []FieldError {
+	var out []FieldError
+	for _, fieldError := range e.Errors {
+		if fieldError.IsCustomField() {
+			out = append(out, fieldError)
+		}
+	}
+	return out
+}
+
+// IsCustomField reports whether the error is about a custom field, which
+// Freshdesk names with a cf_ prefix.
+func (e FieldError) IsCustomField() bool {
+	return strings.HasPrefix(e.Field, "cf_")
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Field, e.Message, e.Code)
+}
+
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+func IsCustomFieldError(err error) bool {
+	return errors.Is(err, ErrCustomField)
+}
@@ -0,0 +1,149 @@
+package freshdesk_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+)
+
+var sentinels = []error{
+	freshdesk.ErrNotFound,
+	freshdesk.ErrUnauthorized,
+	freshdesk.ErrForbidden,
+	freshdesk.ErrRateLimited,
+	freshdesk.ErrValidation,
+	freshdesk.ErrCustomField,
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		headers     map[string]string
+		body        string
+		description string
+		errors      []freshdesk.FieldError
+		retryAfter  time.Duration
+		matches     []error
+	}{
+		{name: "not found", status: http.StatusNotFound, matches: []error{freshdesk.ErrNotFound}},
+		{
+			name:        "unauthorized",
+			status:      http.StatusUnauthorized,
+			body:        `{"code":"invalid_credentials","message":"You have to be logged in to perform this action."}`,
+			description: "You have to be logged in to perform this action.",
+			matches:     []error{freshdesk.ErrUnauthorized},
+		},
+		{
+			name:        "forbidden",
+			status:      http.StatusForbidden,
+			body:        `{"code":"access_denied","message":"You are not authorized to perform this action."}`,
+			description: "You are not authorized to perform this action.",
+			matches:     []error{freshdesk.ErrForbidden},
+		},
+		{
+			name:       "rate limited",
+			status:     http.StatusTooManyRequests,
+			headers:    map[string]string{"Retry-After": "17"},
+			retryAfter: 17 * time.Second,
+			matches:    []error{freshdesk.ErrRateLimited},
+		},
+		{
+			name:        "validation",
+			status:      http.StatusBadRequest,
+			body:        `{"description":"Validation failed","errors":[{"field":"email","message":"It should be a valid email address","code":"invalid_value"}]}`,
+			description: "Validation failed",
+			errors:      []freshdesk.FieldError{{Field: "email", Message: "It should be a valid email address", Code: "invalid_value"}},
+			matches:     []error{freshdesk.ErrValidation},
+		},
+		{
+			name:        "custom field",
+			status:      http.StatusBadRequest,
+			body:        `{"description":"Validation failed","errors":[{"field":"cf_floor","message":"It should be a/an Integer","code":"datatype_mismatch"}]}`,
+			description: "Validation failed",
+			errors:      []freshdesk.FieldError{{Field: "cf_floor", Message: "It should be a/an Integer", Code: "datatype_mismatch"}},
+			matches:     []error{freshdesk.ErrValidation, freshdesk.ErrCustomField},
+		},
+		{name: "server error", status: http.StatusInternalServerError, body: "<html>oops</html>"},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-Id", "req-1")
+			for key, value := range test.headers {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		}))
+		client := freshdesk.Init("fake", "key", &freshdesk.ClientOptions{BaseURL: server.URL, RetryPolicy: freshdesk.NoRetryPolicy()})
+		_, err := client.Tickets.View(1)
+		server.Close()
+
+		apiError := freshdesk.APIError{}
+		if !errors.As(err, &apiError) {
+			t.Errorf("%s: err = %v, want an APIError", test.name, err)
+			continue
+		}
+		if apiError.StatusCode != test.status || apiError.ExpectedStatus != http.StatusOK || apiError.Method != http.MethodGet ||
+			apiError.Path != "/api/v2/tickets/1" || apiError.RequestID != "req-1" {
+			t.Errorf("%s: got %+v", test.name, apiError)
+		}
+		if apiError.Description != test.description {
+			t.Errorf("%s: Description = %q, want %q", test.name, apiError.Description, test.description)
+		}
+		if !reflect.DeepEqual(apiError.Errors, test.errors) {
+			t.Errorf("%s: Errors = %+v, want %+v", test.name, apiError.Errors, test.errors)
+		}
+		if apiError.RetryAfter != test.retryAfter {
+			t.Errorf("%s: RetryAfter = %v, want %v", test.name, apiError.RetryAfter, test.retryAfter)
+		}
+		if test.body != "" && apiError.APIError == "" {
+			t.Errorf("%s: the body was not kept", test.name)
+		}
+		for _, sentinel := range sentinels {
+			want := false
+			for _, match := range test.matches {
+				want = want || match == sentinel
+			}
+			if got := errors.Is(err, sentinel); got != want {
+				t.Errorf("%s: errors.Is(err, %v) = %v, want %v", test.name, sentinel, got, want)
+			}
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  freshdesk.APIError
+		want string
+	}{
+		{freshdesk.APIError{StatusCode: 404, ExpectedStatus: 200}, "received status code 404 (200 expected)"},
+		{freshdesk.APIError{StatusCode: 404, ExpectedStatus: 200, Method: "GET", Path: "/api/v2/tickets/1"},
+			"GET /api/v2/tickets/1: received status code 404 (200 expected)"},
+		{
+			freshdesk.APIError{
+				StatusCode:     400,
+				ExpectedStatus: 201,
+				Method:         "POST",
+				Path:           "/api/v2/tickets",
+				Description:    "Validation failed",
+				Errors: []freshdesk.FieldError{
+					{Field: "email", Message: "It should be a valid email address", Code: "invalid_value"},
+					{Message: "Unexpected/invalid field in request", Code: "invalid_field"},
+				},
+			},
+			"POST /api/v2/tickets: received status code 400 (201 expected): Validation failed; " +
+				"email: It should be a valid email address (invalid_value); Unexpected/invalid field in request (invalid_field)",
+		},
+	}
+	for _, test := range tests {
+		if got := test.err.Error(); got != test.want {
+			t.Errorf("Error() = %q, want %q", got, test.want)
+		}
+	}
+}
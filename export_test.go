@@ -0,0 +1,28 @@
+package freshdesk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Internals exercised by the tests of package freshdesk_test, which cannot
+// live in this package as they use the fake server of freshdesktest.
+
+var ParseRetryAfter = parseRetryAfter
+
+func (policy *RetryPolicy) RetryDelay(method string, attempt int, res *http.Response, err error) (time.Duration, bool) {
+	return policy.retryDelay(method, attempt, res, err)
+}
+
+type RequestBudget = requestBudget
+
+func NewRequestBudget(requestsPerMinute, burst int) *RequestBudget {
+	return newRequestBudget(requestsPerMinute, burst)
+}
+
+func (budget *requestBudget) Wait(ctx context.Context) error {
+	return budget.wait(ctx)
+}
+
+var ParseLinkHeader = parseLinkHeader
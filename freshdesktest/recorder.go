@@ -0,0 +1,329 @@
+package freshdesktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+)
+
+// RecorderMode selects whether a Recorder talks to Freshdesk.
+type RecorderMode int
+
+const (
+	// ModeReplay serves every request from the cassette and fails requests
+	// that were not recorded.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends every request to Freshdesk and records it.
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette when the file exists and
+	// records a new one otherwise.
+	ModeReplayOrRecord
+)
+
+const scrubbed = "[SCRUBBED]"
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request struct {
+		Method string `json:"method"`
+		// URL is the path and query; the account's host is not recorded.
+		URL  string `json:"url"`
+		Body string `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		Status  int         `json:"status"`
+		Headers http.Header `json:"headers"`
+		Body    string      `json:"body,omitempty"`
+	} `json:"response"`
+}
+
+// Recorder is an http.RoundTripper that records the interactions of an
+// ApiClient to a cassette file and replays them offline:
+//
+//	recorder, err := freshdesktest.NewRecorder("testdata/search.json", freshdesktest.ModeReplayOrRecord)
+//	defer recorder.Save()
+//	client := freshdesk.Init(domain, apiKey, &freshdesk.ClientOptions{Transport: recorder})
+//
+// Request headers, including the basic-auth API key, are never recorded, and
+// the values of ScrubFields are replaced in bodies, query parameters and
+// search queries. Replayed requests are matched on method and URL, after the
+// same scrubbing, in the order they were recorded.
+type Recorder struct {
+	// Transport sends requests while recording; http.DefaultTransport when nil.
+	Transport http.RoundTripper
+	// ScrubFields are the JSON fields, query parameters and search query
+	// fields whose values are replaced; freshdesk.DefaultRedactFields when
+	// nil.
+	ScrubFields []string
+
+	path         string
+	recording    bool
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder opens the cassette at path, loading it unless recording.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	recorder := &Recorder{
+		path:      path,
+		recording: mode == ModeRecord,
+	}
+	if mode == ModeReplayOrRecord {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			recorder.recording = true
+		}
+	}
+	if recorder.recording {
+		return recorder, nil
+	}
+
+	jsonb, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(jsonb, &recorder.interactions); err != nil {
+		return nil, fmt.Errorf("freshdesktest: reading cassette %s: %w", path, err)
+	}
+	recorder.used = make([]bool, len(recorder.interactions))
+	return recorder, nil
+}
+
+// Recording reports whether requests are sent to Freshdesk.
+func (recorder *Recorder) Recording() bool {
+	return recorder.recording
+}
+
+// RoundTrip implements http.RoundTripper.
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	fields := recorder.scrubFields()
+	requestURL := scrubURL(req.URL, fields)
+
+	if !recorder.recording {
+		return recorder.replay(req, requestURL)
+	}
+
+	transport := recorder.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	interaction := Interaction{}
+	interaction.Request.Method = req.Method
+	interaction.Request.URL = requestURL
+	interaction.Request.Body = scrubBody(body, req.Header.Get("Content-Type"), fields)
+	interaction.Response.Status = res.StatusCode
+	interaction.Response.Headers = scrubHeaders(res.Header)
+	interaction.Response.Body = scrubBody(resBody, res.Header.Get("Content-Type"), fields)
+
+	recorder.mu.Lock()
+	recorder.interactions = append(recorder.interactions, interaction)
+	recorder.mu.Unlock()
+	return res, nil
+}
+
+func (recorder *Recorder) replay(req *http.Request, requestURL string) (*http.Response, error) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	for i, interaction := range recorder.interactions {
+		if recorder.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != requestURL {
+			continue
+		}
+		recorder.used[i] = true
+
+		headers := http.Header{}
+		for key, values := range interaction.Response.Headers {
+			headers[key] = append([]string{}, values...)
+		}
+		// Links were recorded without the account's host.
+		if link := headers.Get("Link"); strings.HasPrefix(link, "</") {
+			headers.Set("Link", "<"+req.URL.Scheme+"://"+req.URL.Host+link[1:])
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        headers,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("freshdesktest: no recorded interaction left for %s %s in %s", req.Method, requestURL, recorder.path)
+}
+
+// Save writes the cassette when recording. It does nothing when replaying.
+func (recorder *Recorder) Save() error {
+	if !recorder.recording {
+		return nil
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	jsonb, err := json.MarshalIndent(recorder.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(recorder.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(recorder.path, append(jsonb, '\n'), 0644)
+}
+
+func (recorder *Recorder) scrubFields() map[string]bool {
+	fields := recorder.ScrubFields
+	if fields == nil {
+		fields = freshdesk.DefaultRedactFields
+	}
+	set := map[string]bool{}
+	for _, field := range fields {
+		set[strings.ToLower(field)] = true
+	}
+	return set
+}
+
+// queryTerm matches a search term with a quoted or bare value, such as
+// email:'a@example.com' or phone:5551234.
+var queryTerm = regexp.MustCompile(`(\w+):([<>]?)('[^']*'|[^\s()'"]+)`)
+
+// scrubURL returns the path and query of u, with the values of scrubbed
+// query parameters and of scrubbed fields in search queries replaced.
+func scrubURL(u *url.URL, fields map[string]bool) string {
+	query := u.Query()
+	for key, values := range query {
+		if fields[strings.ToLower(key)] {
+			for i := range values {
+				values[i] = scrubbed
+			}
+		}
+	}
+	if search := query.Get("query"); search != "" {
+		query.Set("query", queryTerm.ReplaceAllStringFunc(search, func(term string) string {
+			parts := queryTerm.FindStringSubmatch(term)
+			if !fields[strings.ToLower(parts[1])] {
+				return term
+			}
+			if strings.HasPrefix(parts[3], "'") {
+				return parts[1] + ":" + parts[2] + "'" + scrubbed + "'"
+			}
+			return parts[1] + ":" + parts[2] + scrubbed
+		}))
+	}
+	if len(query) == 0 {
+		return u.Path
+	}
+	return u.Path + "?" + query.Encode()
+}
+
+func scrubHeaders(headers http.Header) http.Header {
+	out := http.Header{}
+	for key, values := range headers {
+		switch http.CanonicalHeaderKey(key) {
+		case "Set-Cookie", "Content-Length", "Date":
+			continue
+		case "Link":
+			// Keep only the path of the next page, the host is the account's.
+			link := values[0]
+			if start, end := strings.Index(link, "<"), strings.Index(link, ">"); start >= 0 && end > start {
+				if u, err := url.Parse(link[start+1 : end]); err == nil {
+					link = link[:start+1] + u.RequestURI() + link[end:]
+				}
+			}
+			out.Set(key, link)
+			continue
+		}
+		out[key] = append([]string{}, values...)
+	}
+	return out
+}
+
+// scrubBody scrubs a JSON body. Other bodies, such as multipart uploads and
+// attachment downloads, may hold file contents and are replaced by their
+// size and media type, so they replay as that placeholder.
+func scrubBody(body []byte, contentType string, fields map[string]bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+		if mediaType == "" {
+			mediaType = "non-JSON content"
+		}
+		return fmt.Sprintf("[%d bytes of %s]", len(body), mediaType)
+	}
+	jsonb, err := json.Marshal(scrubValue(value, fields))
+	if err != nil {
+		return scrubbed
+	}
+	return string(jsonb)
+}
+
+func scrubValue(value interface{}, fields map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if fields[strings.ToLower(key)] {
+				v[key] = scrubLeaves(inner)
+				continue
+			}
+			v[key] = scrubValue(inner, fields)
+		}
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = scrubValue(inner, fields)
+		}
+	}
+	return value
+}
+
+// scrubLeaves replaces every string and number in value, keeping its JSON
+// types so that replayed bodies still decode into the freshdesk types.
+func scrubLeaves(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return scrubbed
+	case float64:
+		return 0
+	case map[string]interface{}:
+		for key, inner := range v {
+			v[key] = scrubLeaves(inner)
+		}
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = scrubLeaves(inner)
+		}
+	}
+	return value
+}
@@ -0,0 +1,134 @@
+package freshdesktest
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
+)
+
+func TestRecorderScrubsCassette(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.APIKey = "secret-api-key"
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := server.Client(&freshdesk.ClientOptions{Transport: recorder})
+	ticket, err := client.Tickets.Create(freshdesk.CreateTicket{
+		Name:        "Ada Lovelace",
+		Email:       "ada@example.com",
+		Subject:     "Invoice",
+		Description: "See attached",
+		Status:      2,
+		Priority:    1,
+		Attachments: []freshdesk.File{{Name: "invoice.txt", Content: strings.NewReader("account 12345678")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := client.Attachments.Download(ticket.Attachments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, content)
+	content.Close()
+	ctx := context.Background()
+	if _, err := client.Tickets.ListWithOptions(&freshdesk.ListTicketsOptions{Email: "ada@example.com"}).All(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Companies.Autocomplete("Analytical Engines"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Contacts.Search(querybuilder.AllOf(
+		querybuilder.Parameter("phone").Equals(5551234),
+		querybuilder.Parameter("language").Is("en"),
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-api-key", "ada@example.com", "ada%40example.com", "Ada Lovelace", "account 12345678", "Analytical", "5551234"} {
+		if strings.Contains(string(cassette), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+	for _, placeholder := range []string{"bytes of multipart/form-data]", "bytes of text/plain]", "email=%5BSCRUBBED%5D", "name=%5BSCRUBBED%5D", "language%3A%27en%27"} {
+		if !strings.Contains(string(cassette), placeholder) {
+			t.Errorf("cassette lacks the placeholder %q", placeholder)
+		}
+	}
+
+	// The JSON interactions still replay.
+	replay, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = freshdesk.Init("fake", "other-key", &freshdesk.ClientOptions{BaseURL: server.URL, Transport: replay})
+	replayed, err := client.Tickets.Create(freshdesk.CreateTicket{Email: "someone@example.com", Subject: "Invoice", Status: 2, Priority: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.ID != ticket.ID || replayed.Subject != "Invoice" {
+		t.Errorf("replayed ticket %d %q, want %d %q", replayed.ID, replayed.Subject, ticket.ID, "Invoice")
+	}
+}
+
+func TestScrubURL(t *testing.T) {
+	fields := map[string]bool{"email": true, "name": true, "phone": true}
+	tests := []struct {
+		url, want string
+	}{
+		{"/api/v2/tickets", "/api/v2/tickets"},
+		{"/api/v2/tickets?email=ada%40example.com&page=2", "/api/v2/tickets?email=[SCRUBBED]&page=2"},
+		{"/api/v2/companies/autocomplete?Name=Acme", "/api/v2/companies/autocomplete?Name=[SCRUBBED]"},
+		{`/api/v2/search/contacts?query="email:'ada@example.com' AND language:'en'"`, `/api/v2/search/contacts?query="email:'[SCRUBBED]' AND language:'en'"`},
+		{`/api/v2/search/contacts?query="phone:5551234 OR mobile:5551234"`, `/api/v2/search/contacts?query="phone:[SCRUBBED] OR mobile:5551234"`},
+		{`/api/v2/search/tickets?query="(name:'Ada' OR priority:>2)"&page=3`, `/api/v2/search/tickets?page=3&query="(name:'[SCRUBBED]' OR priority:>2)"`},
+	}
+	for _, test := range tests {
+		u, err := url.Parse(test.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := url.QueryUnescape(scrubURL(u, fields))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("scrubURL(%s) = %s, want %s", test.url, got, test.want)
+		}
+	}
+}
+
+func TestScrubBody(t *testing.T) {
+	fields := map[string]bool{"email": true}
+	tests := []struct {
+		body, contentType, want string
+	}{
+		{`{"email":"ada@example.com","status":2}`, "application/json", `{"email":"[SCRUBBED]","status":2}`},
+		{"--boundary\r\nfile contents", "multipart/form-data; boundary=boundary", "[25 bytes of multipart/form-data]"},
+		{"%PDF-1.4", "", "[8 bytes of non-JSON content]"},
+		{"", "text/plain", ""},
+	}
+	for _, test := range tests {
+		if got := scrubBody([]byte(test.body), test.contentType, fields); got != test.want {
+			t.Errorf("scrubBody(%q, %q) = %q, want %q", test.body, test.contentType, got, test.want)
+		}
+	}
+}
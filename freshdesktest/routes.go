@@ -0,0 +1,1446 @@
+package freshdesktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+)
+
+type request struct {
+	server *Server
+	r      *http.Request
+	body   []byte
+	ids    []int64
+}
+
+// object decodes the request body, JSON or multipart/form-data. Uploaded
+// files are stored and listed under attachments.
+func (req *request) object() (object, bool) {
+	mediaType, params, _ := mime.ParseMediaType(req.r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		return req.multipartObject(params["boundary"])
+	}
+	obj := object{}
+	if err := json.Unmarshal(req.body, &obj); err != nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+// Form fields that Freshdesk converts from strings.
+var (
+	numberFields = map[string]bool{"status": true, "priority": true, "source": true}
+	boolFields   = map[string]bool{"private": true, "incoming": true, "include_quoted_text": true, "include_original_attachments": true}
+)
+
+func (req *request) multipartObject(boundary string) (object, bool) {
+	reader := multipart.NewReader(bytes.NewReader(req.body), boundary)
+	obj := object{}
+	attachments := []interface{}{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, false
+		}
+		name := part.FormName()
+		if part.FileName() != "" {
+			if name == "attachments[]" {
+				attachments = append(attachments, req.server.storeAttachment(part.FileName(), part.Header.Get("Content-Type"), content))
+			}
+			continue
+		}
+
+		value := interface{}(string(content))
+		if numberFields[name] || strings.HasSuffix(name, "_id") {
+			if n, err := strconv.ParseInt(string(content), 10, 64); err == nil {
+				value = float64(n)
+			}
+		} else if boolFields[name] {
+			value = string(content) == "true"
+		}
+		if key := strings.TrimSuffix(name, "[]"); key != name {
+			values, _ := obj[key].([]interface{})
+			obj[key] = append(values, value)
+		} else if open := strings.IndexByte(name, '['); open > 0 && strings.HasSuffix(name, "]") {
+			key := name[:open]
+			fields, _ := obj[key].(map[string]interface{})
+			if fields == nil {
+				fields = map[string]interface{}{}
+			}
+			fields[name[open+1:len(name)-1]] = value
+			obj[key] = fields
+		} else {
+			obj[name] = value
+		}
+	}
+	if len(attachments) > 0 {
+		obj["attachments"] = attachments
+	}
+	return obj, true
+}
+
+type handler func(*Server, *request) (int, interface{}, map[string]string)
+
+type route struct {
+	method string
+	// path with {id} placeholders, e.g. api/v2/tickets/{id}/reply.
+	path   string
+	handle handler
+}
+
+func (rt route) match(method string, segments []string) ([]int64, bool) {
+	if rt.method != method {
+		return nil, false
+	}
+	pattern := strings.Split(rt.path, "/")
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+	ids := []int64{}
+	for i, part := range pattern {
+		if part == "{id}" {
+			id, err := strconv.ParseInt(segments[i], 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			ids = append(ids, id)
+			continue
+		}
+		if part != segments[i] {
+			return nil, false
+		}
+	}
+	return ids, true
+}
+
+var routes = []route{
+	{http.MethodGet, "api/v2/agents", listHandler("agents", nil)},
+	{http.MethodGet, "api/v2/agents/me", (*Server).me},
+	{http.MethodGet, "api/v2/agents/{id}", viewHandler("agents")},
+
+	{http.MethodGet, "api/v2/companies", listHandler("companies", nil)},
+	{http.MethodPost, "api/v2/companies", (*Server).createCompany},
+	{http.MethodGet, "api/v2/companies/autocomplete", (*Server).autocompleteCompanies},
+	{http.MethodGet, "api/v2/companies/{id}", viewHandler("companies")},
+	{http.MethodPut, "api/v2/companies/{id}", updateHandler("companies")},
+	{http.MethodDelete, "api/v2/companies/{id}", deleteHandler("companies")},
+	{http.MethodGet, "api/v2/search/companies", searchHandler("companies", nil)},
+
+	{http.MethodGet, "api/v2/contacts", listHandler("contacts", notDeleted)},
+	{http.MethodPost, "api/v2/contacts", (*Server).createContact},
+	{http.MethodGet, "api/v2/contacts/{id}", viewHandler("contacts")},
+	{http.MethodPut, "api/v2/contacts/{id}", updateHandler("contacts")},
+	{http.MethodDelete, "api/v2/contacts/{id}", softDeleteHandler("contacts")},
+	{http.MethodGet, "api/v2/search/contacts", searchHandler("contacts", notDeleted)},
+
+	{http.MethodGet, "api/v2/groups", listHandler("groups", nil)},
+	{http.MethodGet, "api/v2/groups/{id}", viewHandler("groups")},
+
+	{http.MethodGet, "api/v2/sla_policies", listHandler("sla_policies", nil)},
+	{http.MethodPut, "api/v2/sla_policies/{id}", updateHandler("sla_policies")},
+
+	{http.MethodGet, "api/v2/solutions/categories", listHandler("categories", nil)},
+	{http.MethodGet, "api/v2/solutions/categories/{id}", viewHandler("categories")},
+	{http.MethodGet, "api/v2/solutions/categories/{id}/folders", childrenHandler("categories", "folders", "category_id")},
+	{http.MethodGet, "api/v2/solutions/folders/{id}", viewHandler("folders")},
+	{http.MethodGet, "api/v2/solutions/folders/{id}/articles", childrenHandler("folders", "articles", "folder_id")},
+	{http.MethodGet, "api/v2/solutions/articles/{id}", viewHandler("articles")},
+	{http.MethodDelete, "api/v2/solutions/articles/{id}", deleteHandler("articles")},
+
+	{http.MethodGet, "api/v2/tickets", (*Server).listTickets},
+	{http.MethodPost, "api/v2/tickets", (*Server).createTicket},
+	{http.MethodGet, "api/v2/tickets/{id}", (*Server).viewTicket},
+	{http.MethodPut, "api/v2/tickets/{id}", (*Server).updateTicket},
+	{http.MethodDelete, "api/v2/tickets/{id}", softDeleteHandler("tickets")},
+	{http.MethodPut, "api/v2/tickets/{id}/restore", restoreHandler("tickets")},
+	{http.MethodPost, "api/v2/tickets/outbound_email", (*Server).createOutboundEmail},
+	{http.MethodPut, "api/v2/tickets/merge", (*Server).mergeTickets},
+	{http.MethodPost, "api/v2/tickets/{id}/notes", (*Server).addNote},
+	{http.MethodPost, "api/v2/tickets/{id}/forward", (*Server).forward},
+	{http.MethodPost, "api/v2/tickets/bulk_update", (*Server).bulkUpdateTickets},
+	{http.MethodPost, "api/v2/tickets/bulk_delete", (*Server).bulkDeleteTickets},
+	{http.MethodGet, "api/v2/jobs/{id}", (*Server).viewJob},
+
+	{http.MethodDelete, "api/v2/attachments/{id}", (*Server).deleteAttachment},
+	{http.MethodPost, "api/v2/tickets/{id}/reply", (*Server).reply},
+	{http.MethodGet, "api/v2/tickets/{id}/conversations", childrenHandler("tickets", "conversations", "ticket_id")},
+	{http.MethodGet, "api/v2/search/tickets", searchHandler("tickets", notDeleted)},
+
+	{http.MethodGet, "api/v2/tickets/{id}/time_entries", childrenHandler("tickets", "time_entries", "ticket_id")},
+	{http.MethodPost, "api/v2/tickets/{id}/time_entries", (*Server).createTimeEntry},
+	{http.MethodGet, "api/v2/time_entries", (*Server).listTimeEntries},
+	{http.MethodPut, "api/v2/time_entries/{id}", updateHandler("time_entries")},
+	{http.MethodPut, "api/v2/time_entries/{id}/toggle_timer", (*Server).toggleTimer},
+	{http.MethodDelete, "api/v2/time_entries/{id}", deleteHandler("time_entries")},
+
+	{http.MethodGet, "api/v2/tickets/{id}/satisfaction_ratings", childrenHandler("tickets", "satisfaction_ratings", "ticket_id")},
+	{http.MethodPost, "api/v2/tickets/{id}/satisfaction_ratings", (*Server).createSatisfactionRating},
+	{http.MethodGet, "api/v2/surveys", listHandler("surveys", nil)},
+	{http.MethodGet, "api/v2/surveys/satisfaction_ratings", (*Server).listSatisfactionRatings},
+
+	{http.MethodGet, "api/v2/ticket_fields", (*Server).listTicketFields},
+	{http.MethodPost, "api/v2/admin/ticket_fields", (*Server).createTicketField},
+	{http.MethodGet, "api/v2/admin/ticket_fields/{id}", viewHandler("ticket_fields")},
+	{http.MethodPut, "api/v2/admin/ticket_fields/{id}", (*Server).updateTicketField},
+	{http.MethodDelete, "api/v2/admin/ticket_fields/{id}", (*Server).deleteTicketField},
+
+	{http.MethodPut, "api/v2/conversations/{id}", (*Server).updateConversation},
+	{http.MethodDelete, "api/v2/conversations/{id}", deleteHandler("conversations")},
+}
+
+func notDeleted(obj object) bool {
+	deleted, _ := obj["deleted"].(bool)
+	spam, _ := obj["spam"].(bool)
+	return !deleted && !spam
+}
+
+func listHandler(name string, filter func(object) bool) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		return paginate(req, s.collection(name).list(filter))
+	}
+}
+
+func viewHandler(name string) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		obj, ok := s.collection(name).get(req.ids[0])
+		if !ok {
+			return notFound()
+		}
+		return http.StatusOK, obj, nil
+	}
+}
+
+// childrenHandler lists the objects of a collection belonging to a parent,
+// e.g. the folders of a category.
+func childrenHandler(parent, name, key string) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		if _, ok := s.collection(parent).get(req.ids[0]); !ok {
+			return notFound()
+		}
+		return paginate(req, s.collection(name).list(byParent(key, req.ids[0])))
+	}
+}
+
+func updateHandler(name string) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		obj, ok := s.collection(name).get(req.ids[0])
+		if !ok {
+			return notFound()
+		}
+		changes, ok := req.object()
+		if !ok {
+			return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+		}
+		s.merge(obj, changes)
+		return http.StatusOK, obj, nil
+	}
+}
+
+func deleteHandler(name string) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		if _, ok := s.collection(name).get(req.ids[0]); !ok {
+			return notFound()
+		}
+		s.collection(name).remove(req.ids[0])
+		return http.StatusNoContent, nil, nil
+	}
+}
+
+// softDeleteHandler flags the object as deleted, as Freshdesk does for
+// tickets and contacts.
+func softDeleteHandler(name string) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		obj, ok := s.collection(name).get(req.ids[0])
+		if !ok || obj["deleted"] == true {
+			return notFound()
+		}
+		obj["deleted"] = true
+		obj["updated_at"] = s.timestamp()
+		return http.StatusNoContent, nil, nil
+	}
+}
+
+func restoreHandler(name string) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		obj, ok := s.collection(name).get(req.ids[0])
+		if !ok || obj["deleted"] != true {
+			return notFound()
+		}
+		obj["deleted"] = false
+		obj["updated_at"] = s.timestamp()
+		return http.StatusNoContent, nil, nil
+	}
+}
+
+// searchHandler implements the /search endpoints: a query in the
+// querybuilder language, at most 10 pages of 30 results and a total count.
+func searchHandler(name string, filter func(object) bool) handler {
+	return func(s *Server, req *request) (int, interface{}, map[string]string) {
+		query := req.r.URL.Query()
+		match, err := parseQuery(query.Get("query"))
+		if err != nil {
+			return validationError(freshdesk.FieldError{Field: "query", Message: err.Error(), Code: "invalid_value"})
+		}
+		page, _, fieldErr := pageParams(query, searchPerPage, searchPerPage)
+		if fieldErr != nil {
+			return validationError(*fieldErr)
+		}
+		if page > maxSearchPages {
+			return validationError(freshdesk.FieldError{Field: "page", Message: "Value must be less than or equal to 10", Code: "invalid_value"})
+		}
+		results := s.collection(name).list(func(obj object) bool {
+			return (filter == nil || filter(obj)) && match(obj)
+		})
+		start := (page - 1) * searchPerPage
+		if start > len(results) {
+			start = len(results)
+		}
+		end := start + searchPerPage
+		if end > len(results) {
+			end = len(results)
+		}
+		return http.StatusOK, map[string]interface{}{
+			"results": results[start:end],
+			"total":   len(results),
+		}, nil
+	}
+}
+
+// merge applies a partial update, merging custom_fields.
+func (s *Server) merge(obj, changes object) {
+	for key, value := range changes {
+		if key == "id" {
+			continue
+		}
+		if custom, ok := value.(map[string]interface{}); ok && key == "custom_fields" {
+			existing, _ := obj[key].(map[string]interface{})
+			if existing == nil {
+				existing = map[string]interface{}{}
+			}
+			for field, fieldValue := range custom {
+				existing[field] = fieldValue
+			}
+			value = existing
+		}
+		obj[key] = value
+	}
+	obj["updated_at"] = s.timestamp()
+}
+
+// create stores a new object with its timestamps and defaults.
+func (s *Server) create(name string, obj object, defaults object) object {
+	for key, value := range defaults {
+		if _, ok := obj[key]; !ok {
+			obj[key] = value
+		}
+	}
+	delete(obj, "id")
+	obj["created_at"] = s.timestamp()
+	obj["updated_at"] = obj["created_at"]
+	return s.collection(name).insert(obj)
+}
+
+func (s *Server) me(req *request) (int, interface{}, map[string]string) {
+	obj, ok := s.collection("agents").get(s.meID)
+	if !ok {
+		return notFound()
+	}
+	return http.StatusOK, obj, nil
+}
+
+func (s *Server) createCompany(req *request) (int, interface{}, map[string]string) {
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	name, _ := obj["name"].(string)
+	if name == "" {
+		return validationError(freshdesk.FieldError{Field: "name", Message: "It should be a/an String", Code: "missing_field"})
+	}
+	for _, existing := range s.collection("companies").list(nil) {
+		if existingName, _ := existing["name"].(string); strings.EqualFold(existingName, name) {
+			return http.StatusConflict, map[string]interface{}{
+				"description": "Validation failed",
+				"errors": []freshdesk.FieldError{{
+					Field:   "name",
+					Message: "It should be a unique value",
+					Code:    "duplicate_value",
+				}},
+			}, nil
+		}
+	}
+	return http.StatusCreated, s.create("companies", obj, object{
+		"domains":       []interface{}{},
+		"custom_fields": map[string]interface{}{},
+	}), nil
+}
+
+// autocompleteCompanies returns the ID and name of companies whose name
+// starts with the name parameter.
+func (s *Server) autocompleteCompanies(req *request) (int, interface{}, map[string]string) {
+	prefix := strings.ToLower(req.r.URL.Query().Get("name"))
+	if prefix == "" {
+		return validationError(freshdesk.FieldError{Field: "name", Message: "It should be a/an String", Code: "missing_field"})
+	}
+	companies := []object{}
+	for _, company := range s.collection("companies").list(nil) {
+		if name, _ := company["name"].(string); strings.HasPrefix(strings.ToLower(name), prefix) {
+			companies = append(companies, object{"id": company["id"], "name": name})
+		}
+	}
+	return http.StatusOK, map[string]interface{}{"companies": companies}, nil
+}
+
+func (s *Server) createContact(req *request) (int, interface{}, map[string]string) {
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	if name, _ := obj["name"].(string); name == "" {
+		return validationError(freshdesk.FieldError{Field: "name", Message: "It should be a/an String", Code: "missing_field"})
+	}
+	if isZero(obj["email"]) && isZero(obj["phone"]) && isZero(obj["mobile"]) && isZero(obj["twitter_id"]) && isZero(obj["unique_external_id"]) {
+		return validationError(freshdesk.FieldError{
+			Field:   "requester_id",
+			Message: "Please fill at least 1 of email, mobile, phone, twitter_id, unique_external_id fields",
+			Code:    "missing_field",
+		})
+	}
+	if email, _ := obj["email"].(string); email != "" {
+		if _, exists := s.contactByEmail(email); exists {
+			return http.StatusConflict, map[string]interface{}{
+				"description": "Validation failed",
+				"errors": []freshdesk.FieldError{{
+					Field:   "email",
+					Message: "It should be a unique value",
+					Code:    "duplicate_value",
+				}},
+			}, nil
+		}
+	}
+	return http.StatusCreated, s.create("contacts", obj, object{
+		"deleted":       false,
+		"tags":          []interface{}{},
+		"other_emails":  []interface{}{},
+		"custom_fields": map[string]interface{}{},
+	}), nil
+}
+
+func (s *Server) contactByEmail(email string) (object, bool) {
+	for _, contact := range s.collection("contacts").list(nil) {
+		if existing, _ := contact["email"].(string); strings.EqualFold(existing, email) {
+			return contact, true
+		}
+	}
+	return nil, false
+}
+
+// listTickets implements the ticket list filters, ordering and embeds.
+func (s *Server) listTickets(req *request) (int, interface{}, map[string]string) {
+	query := req.r.URL.Query()
+	filters := []func(object) bool{}
+	switch filter := query.Get("filter"); filter {
+	case "":
+		filters = append(filters, notDeleted)
+	case "new_and_my_open":
+		filters = append(filters, notDeleted, func(obj object) bool {
+			status := toInt64(obj["status"])
+			return status == 2 && (isZero(obj["responder_id"]) || toInt64(obj["responder_id"]) == s.meID)
+		})
+	case "watching":
+		// The fake has no watchers.
+		filters = append(filters, func(object) bool { return false })
+	case "spam":
+		filters = append(filters, func(obj object) bool { return obj["spam"] == true && obj["deleted"] != true })
+	case "deleted":
+		filters = append(filters, func(obj object) bool { return obj["deleted"] == true })
+	default:
+		return validationError(freshdesk.FieldError{Field: "filter", Message: "It should be one of these values: 'new_and_my_open,watching,spam,deleted'", Code: "invalid_value"})
+	}
+	for _, key := range []string{"requester_id", "company_id"} {
+		if value := query.Get(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return validationError(freshdesk.FieldError{Field: key, Message: "It should be a/an Positive Integer", Code: "datatype_mismatch"})
+			}
+			key := key
+			filters = append(filters, func(obj object) bool { return toInt64(obj[key]) == id })
+		}
+	}
+	if email := query.Get("email"); email != "" {
+		contact, ok := s.contactByEmail(email)
+		if !ok {
+			return http.StatusOK, []object{}, nil
+		}
+		filters = append(filters, func(obj object) bool { return toInt64(obj["requester_id"]) == contact.id() })
+	}
+	if value := query.Get("updated_since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return validationError(freshdesk.FieldError{Field: "updated_since", Message: "It should be in the 'valid date' format", Code: "invalid_value"})
+		}
+		filters = append(filters, func(obj object) bool {
+			updatedString, _ := obj["updated_at"].(string)
+			updatedAt, _ := time.Parse(time.RFC3339, updatedString)
+			return !updatedAt.Before(since)
+		})
+	}
+	tickets := s.collection("tickets").list(func(obj object) bool {
+		for _, filter := range filters {
+			if !filter(obj) {
+				return false
+			}
+		}
+		return true
+	})
+
+	orderBy, orderType := query.Get("order_by"), query.Get("order_type")
+	if orderBy != "" || orderType != "" {
+		if orderBy == "" {
+			orderBy = "created_at"
+		}
+		switch orderBy {
+		case "created_at", "due_by", "updated_at", "status":
+		default:
+			return validationError(freshdesk.FieldError{Field: "order_by", Message: "It should be one of these values: 'created_at,due_by,updated_at,status'", Code: "invalid_value"})
+		}
+		descending := orderType != "asc"
+		sort.SliceStable(tickets, func(i, j int) bool {
+			a, b := fmt.Sprint(tickets[i][orderBy]), fmt.Sprint(tickets[j][orderBy])
+			if orderBy == "status" {
+				a, b = fmt.Sprintf("%020d", toInt64(tickets[i][orderBy])), fmt.Sprintf("%020d", toInt64(tickets[j][orderBy]))
+			}
+			if descending {
+				return a > b
+			}
+			return a < b
+		})
+	}
+
+	if include := query.Get("include"); include != "" {
+		for i, ticket := range tickets {
+			tickets[i] = s.embed(ticket.clone(), strings.Split(include, ","))
+		}
+	}
+	return paginate(req, tickets)
+}
+
+// phoneNumber returns a contact's phone number as the string the API uses;
+// freshdesk.User stores them as numbers.
+func phoneNumber(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if n, ok := value.(float64); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return value
+}
+
+// embed adds the requested related objects to a copy of a ticket.
+func (s *Server) embed(ticket object, include []string) object {
+	for _, name := range include {
+		switch name {
+		case "requester":
+			if contact, ok := s.collection("contacts").get(toInt64(ticket["requester_id"])); ok {
+				ticket["requester"] = object{
+					"id":     contact["id"],
+					"name":   contact["name"],
+					"email":  contact["email"],
+					"mobile": phoneNumber(contact["mobile"]),
+					"phone":  phoneNumber(contact["phone"]),
+				}
+			}
+		case "company":
+			if company, ok := s.collection("companies").get(toInt64(ticket["company_id"])); ok {
+				ticket["company"] = object{"id": company["id"], "name": company["name"]}
+			}
+		case "stats":
+			stats := object{}
+			for _, key := range []string{"agent_responded_at", "requester_responded_at", "first_responded_at", "status_updated_at", "reopened_at", "resolved_at", "closed_at", "pending_since"} {
+				stats[key] = nil
+			}
+			switch toInt64(ticket["status"]) {
+			case 3:
+				stats["pending_since"] = ticket["updated_at"]
+			case 4:
+				stats["resolved_at"] = ticket["updated_at"]
+			case 5:
+				stats["resolved_at"] = ticket["updated_at"]
+				stats["closed_at"] = ticket["updated_at"]
+			}
+			ticket["stats"] = stats
+		}
+	}
+	return ticket
+}
+
+var htmlTags = regexp.MustCompile(`<[^>]*>`)
+
+func (s *Server) createTicket(req *request) (int, interface{}, map[string]string) {
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	if isZero(obj["requester_id"]) && isZero(obj["email"]) && isZero(obj["phone"]) && isZero(obj["facebook_id"]) && isZero(obj["twitter_id"]) && isZero(obj["unique_external_id"]) {
+		return validationError(freshdesk.FieldError{
+			Field:   "requester_id",
+			Message: "Please fill at least 1 of requester_id, phone, email, twitter_id, facebook_id, unique_external_id fields",
+			Code:    "missing_field",
+		})
+	}
+	if errors := s.validateTicket(obj); len(errors) > 0 {
+		return validationError(errors...)
+	}
+
+	return http.StatusCreated, s.storeTicket(obj, 2, 2), nil
+}
+
+// storeTicket creates a ticket, and its requester from the email when the
+// contact does not exist yet.
+func (s *Server) storeTicket(obj object, status, source int) object {
+	if email, _ := obj["email"].(string); email != "" && isZero(obj["requester_id"]) {
+		contact, exists := s.contactByEmail(email)
+		if !exists {
+			name, _ := obj["name"].(string)
+			if name == "" {
+				name = email
+			}
+			contact = s.create("contacts", object{"name": name, "email": email}, object{
+				"deleted":       false,
+				"tags":          []interface{}{},
+				"other_emails":  []interface{}{},
+				"custom_fields": map[string]interface{}{},
+			})
+		}
+		obj["requester_id"] = contact.id()
+	}
+	if description, ok := obj["description"].(string); ok {
+		obj["description_text"] = htmlTags.ReplaceAllString(description, "")
+	}
+	return s.create("tickets", obj, object{
+		"status":        status,
+		"priority":      1,
+		"source":        source,
+		"deleted":       false,
+		"spam":          false,
+		"is_escalated":  false,
+		"fr_escalated":  false,
+		"tags":          []interface{}{},
+		"cc_emails":     []interface{}{},
+		"fwd_emails":    []interface{}{},
+		"to_emails":     nil,
+		"attachments":   []interface{}{},
+		"custom_fields": map[string]interface{}{},
+	})
+}
+
+// createOutboundEmail creates a closed ticket, as Freshdesk does for
+// outbound emails unless a status is given.
+func (s *Server) createOutboundEmail(req *request) (int, interface{}, map[string]string) {
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	errors := []freshdesk.FieldError{}
+	for _, field := range []string{"email", "subject", "description", "email_config_id"} {
+		if isZero(obj[field]) {
+			errors = append(errors, freshdesk.FieldError{Field: field, Message: "It should not be blank as this is a mandatory field", Code: "missing_field"})
+		}
+	}
+	if len(errors) > 0 {
+		return validationError(errors...)
+	}
+	return http.StatusCreated, s.storeTicket(obj, 5, 10), nil
+}
+
+// mergeTickets closes the secondary tickets and adds the merge notes.
+func (s *Server) mergeTickets(req *request) (int, interface{}, map[string]string) {
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	primary, ok := s.collection("tickets").get(toInt64(obj["primary_id"]))
+	if !ok || primary["deleted"] == true {
+		return validationError(freshdesk.FieldError{Field: "primary_id", Message: "There is no ticket matching the given primary_id", Code: "invalid_value"})
+	}
+	rawIDs, _ := obj["ticket_ids"].([]interface{})
+	if len(rawIDs) == 0 {
+		return validationError(freshdesk.FieldError{Field: "ticket_ids", Message: "It should be a/an Array", Code: "missing_field"})
+	}
+	secondaries := []object{}
+	for _, rawID := range rawIDs {
+		secondary, ok := s.collection("tickets").get(toInt64(rawID))
+		if !ok || secondary["deleted"] == true || secondary.id() == primary.id() {
+			return validationError(freshdesk.FieldError{Field: "ticket_ids", Message: "There are no tickets matching the given ticket_ids", Code: "invalid_value"})
+		}
+		secondaries = append(secondaries, secondary)
+	}
+
+	note := func(ticket object, key, defaultBody string) {
+		body, private := defaultBody, true
+		if custom, ok := obj[key].(map[string]interface{}); ok {
+			body, _ = custom["body"].(string)
+			private, _ = custom["private"].(bool)
+		}
+		s.create("conversations", object{
+			"body":      body,
+			"body_text": htmlTags.ReplaceAllString(body, ""),
+			"ticket_id": ticket.id(),
+			"user_id":   s.meID,
+			"private":   private,
+		}, object{"incoming": false, "source": 2})
+	}
+	for _, secondary := range secondaries {
+		note(secondary, "note_in_secondary", fmt.Sprintf("This ticket is closed and merged into ticket %d", primary.id()))
+		secondary["status"] = 5
+		secondary["updated_at"] = s.timestamp()
+	}
+	note(primary, "note_in_primary", "Merged tickets into this ticket")
+	primary["updated_at"] = s.timestamp()
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) addNote(req *request) (int, interface{}, map[string]string) {
+	ticket, ok := s.collection("tickets").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	body, _ := obj["body"].(string)
+	if body == "" {
+		return validationError(freshdesk.FieldError{Field: "body", Message: "It should be a/an String", Code: "missing_field"})
+	}
+	obj["body_text"] = htmlTags.ReplaceAllString(body, "")
+	obj["ticket_id"] = req.ids[0]
+	if isZero(obj["user_id"]) {
+		obj["user_id"] = s.meID
+	}
+	if notify, ok := obj["notify_emails"]; ok {
+		obj["to_emails"] = notify
+		delete(obj, "notify_emails")
+	}
+	note := s.create("conversations", obj, object{
+		"incoming":    false,
+		"private":     true,
+		"source":      2,
+		"to_emails":   []interface{}{},
+		"attachments": []interface{}{},
+	})
+	ticket["updated_at"] = note["created_at"]
+	return http.StatusCreated, note, nil
+}
+
+// updateConversation edits a note; replies can't be edited.
+func (s *Server) updateConversation(req *request) (int, interface{}, map[string]string) {
+	conversation, ok := s.collection("conversations").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	if toInt64(conversation["source"]) != 2 {
+		return http.StatusMethodNotAllowed, map[string]interface{}{
+			"code":    "method_not_allowed",
+			"message": "PUT method is not allowed. It should be one of these method(s): DELETE",
+		}, nil
+	}
+	changes, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	if body, ok := changes["body"].(string); ok {
+		changes["body_text"] = htmlTags.ReplaceAllString(body, "")
+	}
+	s.merge(conversation, changes)
+	return http.StatusOK, conversation, nil
+}
+
+func (s *Server) forward(req *request) (int, interface{}, map[string]string) {
+	ticket, ok := s.collection("tickets").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	if toEmails, _ := obj["to_emails"].([]interface{}); len(toEmails) == 0 {
+		return validationError(freshdesk.FieldError{Field: "to_emails", Message: "It should be a/an Array", Code: "missing_field"})
+	}
+	body, _ := obj["body"].(string)
+	obj["body_text"] = htmlTags.ReplaceAllString(body, "")
+	obj["ticket_id"] = req.ids[0]
+	obj["user_id"] = obj["agent_id"]
+	if isZero(obj["user_id"]) {
+		obj["user_id"] = s.meID
+	}
+	delete(obj, "agent_id")
+	delete(obj, "include_quoted_text")
+	delete(obj, "include_original_attachments")
+	forward := s.create("conversations", obj, object{
+		"incoming":    false,
+		"private":     true,
+		"source":      8,
+		"cc_emails":   []interface{}{},
+		"bcc_emails":  []interface{}{},
+		"attachments": []interface{}{},
+	})
+	ticket["updated_at"] = forward["created_at"]
+	return http.StatusCreated, forward, nil
+}
+
+// viewTicket returns a ticket unless it was deleted; deleted tickets are
+// only listed with the deleted filter until they are restored.
+func (s *Server) viewTicket(req *request) (int, interface{}, map[string]string) {
+	obj, ok := s.collection("tickets").get(req.ids[0])
+	if !ok || obj["deleted"] == true {
+		return notFound()
+	}
+	return http.StatusOK, obj, nil
+}
+
+func (s *Server) updateTicket(req *request) (int, interface{}, map[string]string) {
+	obj, ok := s.collection("tickets").get(req.ids[0])
+	if !ok || obj["deleted"] == true {
+		return notFound()
+	}
+	changes, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	// Custom fields are checked together with the stored ones, which the
+	// changed values are merged into.
+	checked := object{}
+	for key, value := range changes {
+		checked[key] = value
+	}
+	if custom, ok := changes["custom_fields"].(map[string]interface{}); ok {
+		merged := map[string]interface{}{}
+		stored, _ := obj["custom_fields"].(map[string]interface{})
+		for field, value := range stored {
+			merged[field] = value
+		}
+		for field, value := range custom {
+			merged[field] = value
+		}
+		checked["custom_fields"] = merged
+	}
+	if errors := s.validateTicket(checked); len(errors) > 0 {
+		return validationError(errors...)
+	}
+	s.merge(obj, changes)
+	return http.StatusOK, obj, nil
+}
+
+// validateTicket checks the fields of a created ticket or of the changes to
+// one.
+func (s *Server) validateTicket(obj object) []freshdesk.FieldError {
+	errors := []freshdesk.FieldError{}
+	if status := toInt64(obj["status"]); obj["status"] != nil && (status < 2 || status > 5) {
+		errors = append(errors, freshdesk.FieldError{Field: "status", Message: "It should be one of these values: '2,3,4,5'", Code: "invalid_value"})
+	}
+	if priority := toInt64(obj["priority"]); obj["priority"] != nil && (priority < 1 || priority > 4) {
+		errors = append(errors, freshdesk.FieldError{Field: "priority", Message: "It should be one of these values: '1,2,3,4'", Code: "invalid_value"})
+	}
+	if customFields, ok := obj["custom_fields"].(map[string]interface{}); ok {
+		errors = append(errors, s.validateCustomFields(customFields)...)
+	}
+	return errors
+}
+
+func (s *Server) reply(req *request) (int, interface{}, map[string]string) {
+	ticket, ok := s.collection("tickets").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	body, _ := obj["body"].(string)
+	if body == "" {
+		return validationError(freshdesk.FieldError{Field: "body", Message: "It should be a/an String", Code: "missing_field"})
+	}
+	obj["body_text"] = htmlTags.ReplaceAllString(body, "")
+	obj["ticket_id"] = req.ids[0]
+	if isZero(obj["user_id"]) {
+		obj["user_id"] = s.meID
+	}
+	reply := s.create("conversations", obj, object{
+		"incoming":    false,
+		"private":     false,
+		"source":      0,
+		"cc_emails":   []interface{}{},
+		"bcc_emails":  []interface{}{},
+		"attachments": []interface{}{},
+	})
+	ticket["updated_at"] = reply["created_at"]
+	return http.StatusCreated, reply, nil
+}
+
+// bulkAction decodes the ids of a bulk_action request body.
+func bulkAction(req *request) (object, []int64, bool) {
+	obj, ok := req.object()
+	if !ok {
+		return nil, nil, false
+	}
+	action, ok := obj["bulk_action"].(map[string]interface{})
+	if !ok {
+		return nil, nil, false
+	}
+	rawIDs, _ := action["ids"].([]interface{})
+	ids := []int64{}
+	for _, id := range rawIDs {
+		ids = append(ids, toInt64(id))
+	}
+	return action, ids, len(ids) > 0
+}
+
+// runJob applies fn to every ticket and stores the results as a finished
+// job; the fake runs bulk actions synchronously.
+func (s *Server) runJob(ids []int64, fn func(ticket object)) (int, interface{}, map[string]string) {
+	results := []interface{}{}
+	failed := 0
+	for _, id := range ids {
+		ticket, ok := s.collection("tickets").get(id)
+		if !ok || ticket["deleted"] == true {
+			failed++
+			results = append(results, object{"id": id, "success": false, "message": "Ticket not found"})
+			continue
+		}
+		fn(ticket)
+		results = append(results, object{"id": id, "success": true})
+	}
+	status := freshdesk.JobStatusSuccess
+	if failed == len(ids) {
+		status = freshdesk.JobStatusFailed
+	} else if failed > 0 {
+		status = freshdesk.JobStatusPartial
+	}
+	job := s.create("jobs", object{"status": status, "data": results}, nil)
+	jobID := strconv.FormatInt(job.id(), 10)
+	return http.StatusAccepted, object{
+		"job_id": jobID,
+		"href":   s.URL + "/api/v2/jobs/" + jobID,
+	}, nil
+}
+
+func (s *Server) bulkUpdateTickets(req *request) (int, interface{}, map[string]string) {
+	action, ids, ok := bulkAction(req)
+	if !ok {
+		return validationError(freshdesk.FieldError{Field: "ids", Message: "It should be a/an Array", Code: "missing_field"})
+	}
+	properties, _ := action["properties"].(map[string]interface{})
+	return s.runJob(ids, func(ticket object) {
+		s.merge(ticket, toObject(properties))
+	})
+}
+
+func (s *Server) bulkDeleteTickets(req *request) (int, interface{}, map[string]string) {
+	_, ids, ok := bulkAction(req)
+	if !ok {
+		return validationError(freshdesk.FieldError{Field: "ids", Message: "It should be a/an Array", Code: "missing_field"})
+	}
+	return s.runJob(ids, func(ticket object) {
+		ticket["deleted"] = true
+		ticket["updated_at"] = s.timestamp()
+	})
+}
+
+func (s *Server) viewJob(req *request) (int, interface{}, map[string]string) {
+	job, ok := s.collection("jobs").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	out := job.clone()
+	out["id"] = strconv.FormatInt(job.id(), 10)
+	return http.StatusOK, out, nil
+}
+
+// storeAttachment stores an uploaded file, downloadable from its
+// attachment_url without credentials like Freshdesk's pre-signed URLs.
+func (s *Server) storeAttachment(name, contentType string, content []byte) object {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	attachment := s.create("attachments", object{
+		"name":         name,
+		"content_type": contentType,
+		"size":         len(content),
+	}, nil)
+	attachment["attachment_url"] = fmt.Sprintf("%s/attachments/%d/%s", s.URL, attachment.id(), url.PathEscape(name))
+	s.files[attachment.id()] = content
+	return attachment.clone()
+}
+
+// deleteAttachment removes an attachment from the ticket or conversation it
+// belongs to.
+func (s *Server) deleteAttachment(req *request) (int, interface{}, map[string]string) {
+	id := req.ids[0]
+	if _, ok := s.collection("attachments").get(id); !ok {
+		return notFound()
+	}
+	s.collection("attachments").remove(id)
+	delete(s.files, id)
+	for _, name := range []string{"tickets", "conversations"} {
+		for _, obj := range s.collection(name).list(nil) {
+			attachments, _ := obj["attachments"].([]interface{})
+			kept := []interface{}{}
+			for _, attachment := range attachments {
+				var attachmentID int64
+				switch a := attachment.(type) {
+				case object:
+					attachmentID = a.id()
+				case map[string]interface{}:
+					attachmentID = toInt64(a["id"])
+				}
+				if attachmentID != id {
+					kept = append(kept, attachment)
+				}
+			}
+			if len(kept) != len(attachments) {
+				obj["attachments"] = kept
+			}
+		}
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// serveAttachment writes the content of an attachment for a GET on
+// /attachments/{id}/{name}.
+func (s *Server) serveAttachment(w http.ResponseWriter, segments []string) {
+	id, err := strconv.ParseInt(segments[1], 10, 64)
+	s.mu.Lock()
+	content, ok := s.files[id]
+	attachment, _ := s.collection("attachments").get(id)
+	contentType, _ := attachment["content_type"].(string)
+	s.mu.Unlock()
+	if err != nil || !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+func (s *Server) createTimeEntry(req *request) (int, interface{}, map[string]string) {
+	ticket, ok := s.collection("tickets").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	if timeSpent, ok := obj["time_spent"].(string); ok {
+		if !validTimeSpent(timeSpent) {
+			return validationError(freshdesk.FieldError{Field: "time_spent", Message: "It should be in the 'hh:mm' format", Code: "invalid_value"})
+		}
+	}
+	obj["ticket_id"] = ticket.id()
+	obj["company_id"] = ticket["company_id"]
+	if isZero(obj["agent_id"]) {
+		obj["agent_id"] = s.meID
+	}
+	now := s.timestamp()
+	running := obj["time_spent"] == nil
+	if value, ok := obj["timer_running"].(bool); ok {
+		running = value
+	}
+	obj["timer_running"] = running
+	if running && obj["start_time"] == nil {
+		obj["start_time"] = now
+	}
+	return http.StatusCreated, s.create("time_entries", obj, object{
+		"billable":    true,
+		"note":        "",
+		"time_spent":  "00:00",
+		"executed_at": now,
+		"start_time":  now,
+	}), nil
+}
+
+func validTimeSpent(s string) bool {
+	var hours, minutes int
+	_, err := fmt.Sscanf(s, "%d:%d", &hours, &minutes)
+	return err == nil && hours >= 0 && minutes >= 0 && minutes < 60
+}
+
+func (s *Server) listTimeEntries(req *request) (int, interface{}, map[string]string) {
+	query := req.r.URL.Query()
+	filters := []func(object) bool{}
+	for _, key := range []string{"company_id", "agent_id"} {
+		if value := query.Get(key); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return validationError(freshdesk.FieldError{Field: key, Message: "It should be a/an Positive Integer", Code: "datatype_mismatch"})
+			}
+			key := key
+			filters = append(filters, func(obj object) bool { return toInt64(obj[key]) == id })
+		}
+	}
+	for _, key := range []string{"executed_after", "executed_before"} {
+		if value := query.Get(key); value != "" {
+			bound, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return validationError(freshdesk.FieldError{Field: key, Message: "It should be in the 'valid date' format", Code: "invalid_value"})
+			}
+			after := key == "executed_after"
+			filters = append(filters, func(obj object) bool {
+				executedString, _ := obj["executed_at"].(string)
+				executedAt, _ := time.Parse(time.RFC3339, executedString)
+				if after {
+					return !executedAt.Before(bound)
+				}
+				return !executedAt.After(bound)
+			})
+		}
+	}
+	if value := query.Get("billable"); value != "" {
+		billable := value == "true"
+		filters = append(filters, func(obj object) bool { return obj["billable"] == billable })
+	}
+	return paginate(req, s.collection("time_entries").list(func(obj object) bool {
+		for _, filter := range filters {
+			if !filter(obj) {
+				return false
+			}
+		}
+		return true
+	}))
+}
+
+// toggleTimer starts a stopped timer, or stops a running one and adds the
+// elapsed minutes to time_spent.
+func (s *Server) toggleTimer(req *request) (int, interface{}, map[string]string) {
+	entry, ok := s.collection("time_entries").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	now := s.Now().UTC()
+	if entry["timer_running"] == true {
+		startString, _ := entry["start_time"].(string)
+		start, _ := time.Parse(time.RFC3339, startString)
+		var hours, minutes int
+		timeSpent, _ := entry["time_spent"].(string)
+		fmt.Sscanf(timeSpent, "%d:%d", &hours, &minutes)
+		total := hours*60 + minutes + int(now.Sub(start).Round(time.Minute)/time.Minute)
+		entry["time_spent"] = fmt.Sprintf("%02d:%02d", total/60, total%60)
+		entry["timer_running"] = false
+	} else {
+		entry["start_time"] = now.Format(time.RFC3339)
+		entry["timer_running"] = true
+	}
+	entry["updated_at"] = s.timestamp()
+	return http.StatusOK, entry, nil
+}
+
+func (s *Server) listSatisfactionRatings(req *request) (int, interface{}, map[string]string) {
+	query := req.r.URL.Query()
+	var since time.Time
+	if value := query.Get("created_since"); value != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, value); err != nil {
+			return validationError(freshdesk.FieldError{Field: "created_since", Message: "It should be in the 'valid date' format", Code: "invalid_value"})
+		}
+	}
+	var userID int64
+	if value := query.Get("user_id"); value != "" {
+		var err error
+		if userID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return validationError(freshdesk.FieldError{Field: "user_id", Message: "It should be a/an Positive Integer", Code: "datatype_mismatch"})
+		}
+	}
+	return paginate(req, s.collection("satisfaction_ratings").list(func(obj object) bool {
+		if userID != 0 && toInt64(obj["user_id"]) != userID {
+			return false
+		}
+		createdString, _ := obj["created_at"].(string)
+		createdAt, _ := time.Parse(time.RFC3339, createdString)
+		return !createdAt.Before(since)
+	}))
+}
+
+// createSatisfactionRating rates a ticket on behalf of its requester, in the
+// first active survey.
+func (s *Server) createSatisfactionRating(req *request) (int, interface{}, map[string]string) {
+	ticket, ok := s.collection("tickets").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	ratings, _ := obj["ratings"].(map[string]interface{})
+	if _, ok := ratings[freshdesk.DefaultQuestion]; !ok {
+		return validationError(freshdesk.FieldError{Field: "ratings", Message: "It should contain default_question", Code: "missing_field"})
+	}
+	var survey object
+	for _, candidate := range s.collection("surveys").list(nil) {
+		if candidate["active"] == true {
+			survey = candidate
+			break
+		}
+	}
+	if survey != nil {
+		questions, _ := survey["questions"].([]interface{})
+		for key, value := range ratings {
+			if !acceptsRating(questions, key, toInt64(value)) {
+				return validationError(freshdesk.FieldError{Field: key, Message: "It should be one of the accepted ratings of the question", Code: "invalid_value"})
+			}
+		}
+		obj["survey_id"] = survey.id()
+	}
+	obj["ticket_id"] = ticket.id()
+	obj["user_id"] = ticket["requester_id"]
+	obj["agent_id"] = ticket["responder_id"]
+	obj["group_id"] = ticket["group_id"]
+	return http.StatusCreated, s.create("satisfaction_ratings", obj, object{"feedback": ""}), nil
+}
+
+// acceptsRating reports whether a survey question accepts a rating; the
+// question with default set is the default_question.
+func acceptsRating(questions []interface{}, key string, rating int64) bool {
+	for _, raw := range questions {
+		question, _ := raw.(map[string]interface{})
+		id, _ := question["id"].(string)
+		if id != key && !(key == freshdesk.DefaultQuestion && question["default"] == true) {
+			continue
+		}
+		accepted, _ := question["accepted_ratings"].([]interface{})
+		for _, value := range accepted {
+			if toInt64(value) == rating {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// validateCustomFields checks custom fields against the stored ticket fields.
+// Nothing is checked until ticket fields are added.
+func (s *Server) validateCustomFields(customFields map[string]interface{}) []freshdesk.FieldError {
+	stored := s.collection("ticket_fields").list(nil)
+	if len(stored) == 0 {
+		return nil
+	}
+	fields := freshdesk.TicketFieldSlice{}
+	for _, obj := range stored {
+		field := freshdesk.TicketField{}
+		fromObject(obj, &field)
+		fields = append(fields, field)
+	}
+	if errors, ok := fields.ValidateCustomFields(customFields).(freshdesk.CustomFieldErrors); ok {
+		return errors
+	}
+	return nil
+}
+
+func (s *Server) listTicketFields(req *request) (int, interface{}, map[string]string) {
+	fields := s.collection("ticket_fields").list(nil)
+	if fieldType := req.r.URL.Query().Get("type"); fieldType != "" {
+		fields = s.collection("ticket_fields").list(func(obj object) bool { return obj["type"] == fieldType })
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return toInt64(fields[i]["position"]) < toInt64(fields[j]["position"])
+	})
+	return http.StatusOK, fields, nil
+}
+
+var fieldTypes = map[string]bool{
+	freshdesk.FieldTypeText:      true,
+	freshdesk.FieldTypeParagraph: true,
+	freshdesk.FieldTypeCheckbox:  true,
+	freshdesk.FieldTypeNumber:    true,
+	freshdesk.FieldTypeDecimal:   true,
+	freshdesk.FieldTypeDate:      true,
+	freshdesk.FieldTypeDropdown:  true,
+	freshdesk.FieldTypeNested:    true,
+}
+
+func (s *Server) createTicketField(req *request) (int, interface{}, map[string]string) {
+	obj, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	errors := []freshdesk.FieldError{}
+	if label, _ := obj["label"].(string); label == "" {
+		errors = append(errors, freshdesk.FieldError{Field: "label", Message: "It should be a/an String", Code: "missing_field"})
+	}
+	if label, _ := obj["label_for_customers"].(string); label == "" {
+		errors = append(errors, freshdesk.FieldError{Field: "label_for_customers", Message: "It should be a/an String", Code: "missing_field"})
+	}
+	if fieldType, _ := obj["type"].(string); !fieldTypes[fieldType] {
+		errors = append(errors, freshdesk.FieldError{Field: "type", Message: "It should be one of the custom field types", Code: "invalid_value"})
+	}
+	choices, _ := obj["choices"].([]interface{})
+	if (obj["type"] == freshdesk.FieldTypeDropdown || obj["type"] == freshdesk.FieldTypeNested) && len(choices) == 0 {
+		errors = append(errors, freshdesk.FieldError{Field: "choices", Message: "It should not be blank", Code: "missing_field"})
+	}
+	if len(errors) > 0 {
+		return validationError(errors...)
+	}
+	obj["name"] = fieldName(obj["label"].(string))
+	for _, existing := range s.collection("ticket_fields").list(nil) {
+		if existing["name"] == obj["name"] {
+			return validationError(freshdesk.FieldError{Field: "label", Message: "It should be a unique value", Code: "duplicate_value"})
+		}
+	}
+	if isZero(obj["position"]) {
+		obj["position"] = len(s.collection("ticket_fields").list(nil)) + 1
+	}
+	obj = s.create("ticket_fields", obj, object{
+		"description":            "",
+		"default":                false,
+		"required_for_closure":   false,
+		"required_for_agents":    false,
+		"required_for_customers": false,
+		"customers_can_edit":     false,
+		"displayed_to_customers": false,
+		"has_section":            false,
+	})
+	s.prepareTicketField(obj)
+	return http.StatusCreated, obj, nil
+}
+
+func (s *Server) updateTicketField(req *request) (int, interface{}, map[string]string) {
+	obj, ok := s.collection("ticket_fields").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	changes, ok := req.object()
+	if !ok {
+		return validationError(freshdesk.FieldError{Message: "Request body has invalid json format", Code: "invalid_json"})
+	}
+	for _, key := range []string{"name", "type", "default"} {
+		if _, ok := changes[key]; ok {
+			return validationError(freshdesk.FieldError{Field: key, Message: "Unexpected/invalid field in request", Code: "invalid_field"})
+		}
+	}
+	s.merge(obj, changes)
+	s.prepareTicketField(obj)
+	return http.StatusOK, obj, nil
+}
+
+func (s *Server) deleteTicketField(req *request) (int, interface{}, map[string]string) {
+	obj, ok := s.collection("ticket_fields").get(req.ids[0])
+	if !ok {
+		return notFound()
+	}
+	if obj["default"] == true {
+		return http.StatusForbidden, map[string]interface{}{
+			"code":    "access_denied",
+			"message": "Default fields cannot be deleted",
+		}, nil
+	}
+	s.collection("ticket_fields").remove(req.ids[0])
+	return http.StatusNoContent, nil, nil
+}
+
+// prepareTicketField fills in the IDs, labels and positions of the choices
+// of a stored field, and the names of its dependent fields.
+func (s *Server) prepareTicketField(obj object) {
+	if choices, ok := obj["choices"].([]interface{}); ok {
+		nextID := int64(0)
+		for _, field := range s.collection("ticket_fields").list(nil) {
+			if fieldChoices, ok := field["choices"].([]interface{}); ok {
+				if id := maxChoiceID(fieldChoices); id > nextID {
+					nextID = id
+				}
+			}
+		}
+		prepareChoices(choices, 0, &nextID)
+	}
+	dependents, _ := obj["dependent_fields"].([]interface{})
+	for i, raw := range dependents {
+		dependent, _ := raw.(map[string]interface{})
+		if dependent == nil {
+			continue
+		}
+		if isZero(dependent["id"]) {
+			dependent["id"] = obj.id()*10 + int64(i) + 1
+		}
+		if isZero(dependent["name"]) {
+			label, _ := dependent["label"].(string)
+			dependent["name"] = fieldName(label)
+		}
+		dependent["ticket_field_id"] = obj.id()
+	}
+}
+
+func prepareChoices(choices []interface{}, parentID int64, nextID *int64) {
+	for i, raw := range choices {
+		choice, _ := raw.(map[string]interface{})
+		if choice == nil {
+			continue
+		}
+		if isZero(choice["id"]) {
+			*nextID++
+			choice["id"] = *nextID
+		}
+		if isZero(choice["label"]) {
+			choice["label"] = choice["value"]
+		}
+		if isZero(choice["position"]) {
+			choice["position"] = i + 1
+		}
+		choice["parent_choice_id"] = parentID
+		if children, ok := choice["choices"].([]interface{}); ok {
+			prepareChoices(children, toInt64(choice["id"]), nextID)
+		}
+	}
+}
+
+func maxChoiceID(choices []interface{}) int64 {
+	max := int64(0)
+	for _, raw := range choices {
+		choice, _ := raw.(map[string]interface{})
+		if id := toInt64(choice["id"]); id > max {
+			max = id
+		}
+		if children, ok := choice["choices"].([]interface{}); ok {
+			if id := maxChoiceID(children); id > max {
+				max = id
+			}
+		}
+	}
+	return max
+}
+
+// fieldName derives the name of a custom field from its label, as Freshdesk
+// does: cf_ followed by the label in lower case with underscores.
+func fieldName(label string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, strings.TrimSpace(label))
+	return "cf_" + name
+}
@@ -0,0 +1,254 @@
+package freshdesktest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// matcher evaluates a parsed search query against a stored object.
+type matcher func(object) bool
+
+// fieldAliases maps search fields to the attribute they filter on.
+var fieldAliases = map[string]string{
+	"agent_id": "responder_id",
+	"tag":      "tags",
+}
+
+// parseQuery parses the query language produced by the querybuilder package:
+// field:value terms with optional > and < operators, combined with AND, OR
+// and parentheses. Values are numbers, 'quoted strings', true, false or null.
+func parseQuery(query string) (matcher, error) {
+	query = strings.TrimSpace(query)
+	if len(query) >= 2 && query[0] == '"' && query[len(query)-1] == '"' {
+		query = query[1 : len(query)-1]
+	}
+	p := &queryParser{input: query}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos:], p.pos)
+	}
+	return m, nil
+}
+
+type queryParser struct {
+	input string
+	pos   int
+}
+
+func (p *queryParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *queryParser) keyword(word string) bool {
+	p.skipSpaces()
+	end := p.pos + len(word)
+	if end <= len(p.input) && p.input[p.pos:end] == word && (end == len(p.input) || p.input[end] == ' ' || p.input[end] == '(') {
+		p.pos = end
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) parseOr() (matcher, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l, r := left, right
+		left = func(obj object) bool { return l(obj) || r(obj) }
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (matcher, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		l, r := left, right
+		left = func(obj object) bool { return l(obj) && r(obj) }
+	}
+	return left, nil
+}
+
+func (p *queryParser) parsePrimary() (matcher, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	if p.input[p.pos] == '(' {
+		p.pos++
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("missing ) at position %d", p.pos)
+		}
+		p.pos++
+		return m, nil
+	}
+	return p.parseTerm()
+}
+
+func (p *queryParser) parseTerm() (matcher, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] == '_' || unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos]))) {
+		p.pos++
+	}
+	field := p.input[start:p.pos]
+	if field == "" || p.pos >= len(p.input) || p.input[p.pos] != ':' {
+		return nil, fmt.Errorf("expected field:value at position %d", start)
+	}
+	p.pos++
+
+	op := byte(0)
+	if p.pos < len(p.input) && (p.input[p.pos] == '>' || p.input[p.pos] == '<') {
+		op = p.input[p.pos]
+		p.pos++
+	}
+
+	var literal interface{}
+	if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+		end := strings.IndexByte(p.input[p.pos+1:], '\'')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string at position %d", p.pos)
+		}
+		literal = p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+	} else {
+		valueStart := p.pos
+		for p.pos < len(p.input) && p.input[p.pos] != ' ' && p.input[p.pos] != ')' {
+			p.pos++
+		}
+		raw := p.input[valueStart:p.pos]
+		switch raw {
+		case "":
+			return nil, fmt.Errorf("missing value for %s", field)
+		case "null":
+			literal = nil
+		case "true":
+			literal = true
+		case "false":
+			literal = false
+		default:
+			n, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for %s", raw, field)
+			}
+			literal = n
+		}
+	}
+
+	if alias, ok := fieldAliases[field]; ok {
+		field = alias
+	}
+	return func(obj object) bool {
+		return matchField(lookupField(obj, field), op, literal)
+	}, nil
+}
+
+// lookupField returns the value of a top-level attribute, falling back to
+// custom_fields.
+func lookupField(obj object, field string) interface{} {
+	if v, ok := obj[field]; ok {
+		return v
+	}
+	if custom, ok := obj["custom_fields"].(map[string]interface{}); ok {
+		return custom[field]
+	}
+	return nil
+}
+
+func matchField(value interface{}, op byte, literal interface{}) bool {
+	if values, ok := value.([]interface{}); ok {
+		if literal == nil {
+			return len(values) == 0
+		}
+		for _, v := range values {
+			if matchValue(v, op, literal) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchValue(value, op, literal)
+}
+
+// matchValue compares a stored value with a query literal. As in Freshdesk,
+// > and < are inclusive, and date literals match timestamps by day.
+func matchValue(value interface{}, op byte, literal interface{}) bool {
+	if literal == nil {
+		return isZero(value)
+	}
+	switch lit := literal.(type) {
+	case bool:
+		b, _ := value.(bool)
+		return op == 0 && b == lit
+	case float64:
+		n, ok := value.(float64)
+		if !ok {
+			n = float64(toInt64(value))
+		}
+		switch op {
+		case '>':
+			return n >= lit
+		case '<':
+			return n <= lit
+		}
+		return n == lit
+	case string:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		if isDate(lit) && len(s) >= len(lit) {
+			s = s[:len(lit)]
+		} else {
+			s, lit = strings.ToLower(s), strings.ToLower(lit)
+		}
+		switch op {
+		case '>':
+			return s >= lit
+		case '<':
+			return s <= lit
+		}
+		return s == lit
+	}
+	return false
+}
+
+func isDate(s string) bool {
+	if len(s) != len("2006-01-02") {
+		return false
+	}
+	for i, r := range s {
+		if i == 4 || i == 7 {
+			if r != '-' {
+				return false
+			}
+		} else if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package freshdesktest
+
+import (
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	ticket := object{
+		"id":           float64(7),
+		"status":       float64(2),
+		"priority":     float64(3),
+		"responder_id": float64(42),
+		"spam":         false,
+		"group_id":     nil,
+		"tags":         []interface{}{"billing", "VIP"},
+		"created_at":   "2024-03-05T23:59:59Z",
+		"custom_fields": map[string]interface{}{
+			"cf_region": "EMEA",
+		},
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`status:2`, true},
+		{`"status:2"`, true},
+		{`status:3`, false},
+		{`priority:>3`, true},
+		{`priority:<3`, true},
+		{`priority:>4`, false},
+		{`status:2 AND priority:3`, true},
+		{`status:2 AND priority:4`, false},
+		{`status:5 OR priority:3`, true},
+		{`(status:5 OR priority:3) AND spam:false`, true},
+		{`status:2 AND (priority:1 OR priority:2)`, false},
+		{`spam:true`, false},
+		{`group_id:null`, true},
+		{`responder_id:null`, false},
+		{`agent_id:42`, true},
+		{`tag:'vip'`, true},
+		{`tag:'sales'`, false},
+		{`cf_region:'emea'`, true},
+		{`created_at:'2024-03-05'`, true},
+		{`created_at:>'2024-03-05'`, true},
+		{`created_at:<'2024-03-05'`, true},
+		{`created_at:>'2024-03-06'`, false},
+		{`created_at:<'2024-03-04'`, false},
+	}
+	for _, test := range tests {
+		match, err := parseQuery(test.query)
+		if err != nil {
+			t.Errorf("parseQuery(%q): %v", test.query, err)
+			continue
+		}
+		if got := match(ticket); got != test.want {
+			t.Errorf("%s matched %v, want %v", test.query, got, test.want)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, query := range []string{
+		``,
+		`status`,
+		`status:`,
+		`status:open`,
+		`subject:'unterminated`,
+		`(status:2`,
+		`status:2 AND`,
+		`status:2 priority:3`,
+	} {
+		if _, err := parseQuery(query); err == nil {
+			t.Errorf("parseQuery(%q) returned no error", query)
+		}
+	}
+}
+
+func TestMatchValue(t *testing.T) {
+	tests := []struct {
+		value   interface{}
+		op      byte
+		literal interface{}
+		want    bool
+	}{
+		// > and < are inclusive, as in Freshdesk.
+		{float64(5), '>', float64(5), true},
+		{float64(5), '<', float64(5), true},
+		{float64(4), '>', float64(5), false},
+		{float64(6), '<', float64(5), false},
+		{float64(5), 0, float64(5), true},
+		// Timestamps match date literals by day.
+		{"2024-01-31T00:00:00Z", 0, "2024-01-31", true},
+		{"2024-01-31T23:59:59Z", '<', "2024-01-31", true},
+		{"2024-01-31T00:00:00Z", '>', "2024-01-31", true},
+		{"2024-02-01T00:00:00Z", '<', "2024-01-31", false},
+		{"2024-01-30T23:59:59Z", '>', "2024-01-31", false},
+		// Other strings compare case-insensitively.
+		{"Open", 0, "open", true},
+		{"b", '>', "A", true},
+		{float64(1), 0, "1", false},
+		{true, 0, true, true},
+		{false, 0, true, false},
+		{nil, 0, true, false},
+		{"", 0, nil, true},
+		{float64(0), 0, nil, true},
+		{"x", 0, nil, false},
+	}
+	for _, test := range tests {
+		if got := matchValue(test.value, test.op, test.literal); got != test.want {
+			t.Errorf("matchValue(%v, %q, %v) = %v, want %v", test.value, test.op, test.literal, got, test.want)
+		}
+	}
+}
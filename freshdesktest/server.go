@@ -0,0 +1,510 @@
+// Package freshdesktest provides an in-memory fake of the Freshdesk v2 API
+// for hermetic tests of code built on the freshdesk package.
+//
+//	server := freshdesktest.NewServer()
+//	defer server.Close()
+//	server.AddTicket(freshdesk.Ticket{Subject: "Printer on fire", Status: 2})
+//	client := server.Client(nil)
+//	tickets, err := client.Tickets.All()
+package freshdesktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+)
+
+const (
+	defaultPerPage = 30
+	maxPerPage     = 100
+	searchPerPage  = 30
+	maxSearchPages = 10
+)
+
+// Server is a fake Freshdesk account served over HTTP. It is safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// APIKey, when set before the first request, is the only API key
+	// accepted; other keys get a 401.
+	APIKey string
+	// Now returns the time used for created_at and updated_at.
+	Now func() time.Time
+
+	mu          sync.Mutex
+	collections map[string]*collection
+	files       map[int64][]byte
+	meID        int64
+	faults      []*Fault
+	requests    []Request
+}
+
+// Fault makes the server misbehave for matching requests.
+type Fault struct {
+	// Method restricts the fault to one HTTP method; empty matches all.
+	Method string
+	// Path restricts the fault to request paths with this prefix; empty
+	// matches all.
+	Path string
+	// Status is the status code returned instead of the normal response.
+	// Zero keeps the normal response, e.g. to only add Latency.
+	Status int
+	// RetryAfter is sent as the Retry-After header, in seconds.
+	RetryAfter int
+	// Latency delays the response.
+	Latency time.Duration
+	// Count is the number of requests the fault applies to; zero means every
+	// matching request.
+	Count int
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// NewServer starts an empty fake account. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		Now:         time.Now,
+		collections: map[string]*collection{},
+		files:       map[int64][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an ApiClient pointed at the server. The options may be nil;
+// their BaseURL is always replaced.
+func (s *Server) Client(options *freshdesk.ClientOptions) freshdesk.ApiClient {
+	opts := freshdesk.ClientOptions{}
+	if options != nil {
+		opts = *options
+	}
+	opts.BaseURL = s.URL
+	apiKey := s.APIKey
+	if apiKey == "" {
+		apiKey = "test-api-key"
+	}
+	return freshdesk.Init("fake", apiKey, &opts)
+}
+
+// InjectFault adds a fault; faults are checked in the order they were added.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) collection(name string) *collection {
+	c, ok := s.collections[name]
+	if !ok {
+		c = newCollection()
+		s.collections[name] = c
+	}
+	return c
+}
+
+func (s *Server) timestamp() string {
+	return s.Now().UTC().Format(time.RFC3339)
+}
+
+// add stores v in the named collection, filling in its ID and timestamps.
+func (s *Server) add(name string, v interface{}, out interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj := toObject(v)
+	for _, key := range []string{"created_at", "updated_at"} {
+		if obj[key] == nil {
+			obj[key] = s.timestamp()
+		}
+	}
+	fromObject(s.collection(name).insert(obj), out)
+}
+
+// AddTicket stores a ticket and returns it with its ID and timestamps set.
+// Its Conversations are stored as well.
+func (s *Server) AddTicket(ticket freshdesk.Ticket) freshdesk.Ticket {
+	conversations := ticket.Conversations
+	ticket.Conversations = nil
+	out := freshdesk.Ticket{}
+	s.add("tickets", ticket, &out)
+	for _, conversation := range conversations {
+		s.AddConversation(out.ID, conversation)
+	}
+	return out
+}
+
+// AddConversation stores a reply or note on a ticket.
+func (s *Server) AddConversation(ticketID int64, conversation freshdesk.Conversation) freshdesk.Conversation {
+	conversation.TicketID = ticketID
+	out := freshdesk.Conversation{}
+	s.add("conversations", conversation, &out)
+	return out
+}
+
+// AddContact stores a contact.
+func (s *Server) AddContact(contact freshdesk.User) freshdesk.User {
+	out := freshdesk.User{}
+	s.add("contacts", contact, &out)
+	return out
+}
+
+// AddCompany stores a company.
+func (s *Server) AddCompany(company freshdesk.Company) freshdesk.Company {
+	out := freshdesk.Company{}
+	s.add("companies", company, &out)
+	return out
+}
+
+// AddAgent stores an agent. The first agent added is returned by /agents/me
+// unless SetMe is called.
+func (s *Server) AddAgent(agent freshdesk.Agent) freshdesk.Agent {
+	out := freshdesk.Agent{}
+	s.add("agents", agent, &out)
+	s.mu.Lock()
+	if s.meID == 0 {
+		s.meID = out.ID
+	}
+	s.mu.Unlock()
+	return out
+}
+
+// SetMe selects the agent returned by /agents/me.
+func (s *Server) SetMe(agentID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meID = agentID
+}
+
+// AddGroup stores a group.
+func (s *Server) AddGroup(group freshdesk.Group) freshdesk.Group {
+	out := freshdesk.Group{}
+	s.add("groups", group, &out)
+	return out
+}
+
+// AddSLAPolicy stores an SLA policy.
+func (s *Server) AddSLAPolicy(policy freshdesk.SLAPolicy) freshdesk.SLAPolicy {
+	out := freshdesk.SLAPolicy{}
+	s.add("sla_policies", policy, &out)
+	return out
+}
+
+// AddCategory stores a solution category.
+func (s *Server) AddCategory(category freshdesk.Category) freshdesk.Category {
+	out := freshdesk.Category{}
+	s.add("categories", category, &out)
+	return out
+}
+
+// AddFolder stores a solution folder in a category.
+func (s *Server) AddFolder(categoryID int64, folder freshdesk.Folder) freshdesk.Folder {
+	obj := toObject(folder)
+	obj["category_id"] = categoryID
+	out := freshdesk.Folder{}
+	s.add("folders", obj, &out)
+	return out
+}
+
+// AddArticle stores a solution article in a folder.
+func (s *Server) AddArticle(folderID int64, article freshdesk.Article) freshdesk.Article {
+	article.FolderID = folderID
+	out := freshdesk.Article{}
+	s.add("articles", article, &out)
+	return out
+}
+
+// AddSurvey stores a satisfaction survey. Ratings created through the API
+// belong to the first active survey.
+func (s *Server) AddSurvey(survey freshdesk.Survey) freshdesk.Survey {
+	out := freshdesk.Survey{}
+	s.add("surveys", survey, &out)
+	return out
+}
+
+// AddSatisfactionRating stores a satisfaction rating on a ticket.
+func (s *Server) AddSatisfactionRating(ticketID int64, rating freshdesk.SatisfactionRating) freshdesk.SatisfactionRating {
+	rating.TicketID = ticketID
+	out := freshdesk.SatisfactionRating{}
+	s.add("satisfaction_ratings", rating, &out)
+	return out
+}
+
+// AddTicketField stores a ticket field definition. Names of custom fields,
+// choice IDs and dependent field names are filled in when missing. Once a
+// field is added, created and updated tickets have their custom fields
+// validated.
+func (s *Server) AddTicketField(field freshdesk.TicketField) freshdesk.TicketField {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj := toObject(field)
+	if isZero(obj["name"]) && !field.Default {
+		obj["name"] = fieldName(field.Label)
+	}
+	for _, key := range []string{"created_at", "updated_at"} {
+		if obj[key] == nil {
+			obj[key] = s.timestamp()
+		}
+	}
+	obj = s.collection("ticket_fields").insert(obj)
+	s.prepareTicketField(obj)
+	out := freshdesk.TicketField{}
+	fromObject(obj, &out)
+	return out
+}
+
+func (s *Server) lookup(name string, id int64, out interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.collection(name).get(id)
+	if ok {
+		fromObject(obj, out)
+	}
+	return ok
+}
+
+// Ticket returns the stored state of a ticket.
+func (s *Server) Ticket(id int64) (freshdesk.Ticket, bool) {
+	out := freshdesk.Ticket{}
+	ok := s.lookup("tickets", id, &out)
+	return out, ok
+}
+
+// Contact returns the stored state of a contact.
+func (s *Server) Contact(id int64) (freshdesk.User, bool) {
+	out := freshdesk.User{}
+	ok := s.lookup("contacts", id, &out)
+	return out, ok
+}
+
+// Company returns the stored state of a company.
+func (s *Server) Company(id int64) (freshdesk.Company, bool) {
+	out := freshdesk.Company{}
+	ok := s.lookup("companies", id, &out)
+	return out, ok
+}
+
+// SLAPolicy returns the stored state of an SLA policy.
+func (s *Server) SLAPolicy(id int64) (freshdesk.SLAPolicy, bool) {
+	out := freshdesk.SLAPolicy{}
+	ok := s.lookup("sla_policies", id, &out)
+	return out, ok
+}
+
+// Article reports the stored state of a solution article.
+func (s *Server) Article(id int64) (freshdesk.Article, bool) {
+	out := freshdesk.Article{}
+	ok := s.lookup("articles", id, &out)
+	return out, ok
+}
+
+// Conversations returns the conversations stored on a ticket.
+func (s *Server) Conversations(ticketID int64) freshdesk.ConversationSlice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := freshdesk.ConversationSlice{}
+	for _, obj := range s.collection("conversations").list(byParent("ticket_id", ticketID)) {
+		conversation := freshdesk.Conversation{}
+		fromObject(obj, &conversation)
+		out = append(out, conversation)
+	}
+	return out
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(fault.RetryAfter))
+			}
+			writeJSON(w, fault.Status, map[string]string{
+				"code":    "fault",
+				"message": http.StatusText(fault.Status),
+			})
+			return
+		}
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method == http.MethodGet && len(segments) >= 2 && segments[0] == "attachments" {
+		s.serveAttachment(w, segments)
+		return
+	}
+
+	if s.APIKey != "" {
+		if user, _, ok := r.BasicAuth(); !ok || user != s.APIKey {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{
+				"code":    "invalid_credentials",
+				"message": "You have to be logged in to perform this action.",
+			})
+			return
+		}
+	}
+
+	for _, route := range routes {
+		ids, ok := route.match(r.Method, segments)
+		if !ok {
+			continue
+		}
+		req := &request{server: s, r: r, body: body, ids: ids}
+		s.mu.Lock()
+		status, payload, headers := route.handle(s, req)
+		// Payloads may be stored objects, so they are encoded before
+		// releasing the lock.
+		var jsonb []byte
+		if payload != nil {
+			jsonb, _ = json.Marshal(payload)
+		}
+		s.mu.Unlock()
+		for key, value := range headers {
+			w.Header().Set(key, value)
+		}
+		writeJSON(w, status, json.RawMessage(jsonb))
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// matchFault returns the first fault applying to r, consuming one of its
+// Count. It must be called with s.mu held.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		if fault.Count > 0 {
+			fault.Count--
+			if fault.Count == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	if raw, ok := payload.(json.RawMessage); payload == nil || ok && raw == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+func validationError(errors ...freshdesk.FieldError) (int, interface{}, map[string]string) {
+	return http.StatusBadRequest, map[string]interface{}{
+		"description": "Validation failed",
+		"errors":      errors,
+	}, nil
+}
+
+func notFound() (int, interface{}, map[string]string) {
+	return http.StatusNotFound, nil, nil
+}
+
+func byParent(key string, id int64) func(object) bool {
+	return func(obj object) bool {
+		return toInt64(obj[key]) == id
+	}
+}
+
+// paginate returns one page of objects and the Link header pointing to the
+// next one, as Freshdesk does for list endpoints.
+func paginate(req *request, objects []object) (int, interface{}, map[string]string) {
+	query := req.r.URL.Query()
+	page, perPage, err := pageParams(query, defaultPerPage, maxPerPage)
+	if err != nil {
+		return validationError(*err)
+	}
+	start := (page - 1) * perPage
+	if start > len(objects) {
+		start = len(objects)
+	}
+	end := start + perPage
+	if end > len(objects) {
+		end = len(objects)
+	}
+	var headers map[string]string
+	if end < len(objects) {
+		query.Set("page", strconv.Itoa(page+1))
+		query.Set("per_page", strconv.Itoa(perPage))
+		next := url.URL{
+			Scheme:   "http",
+			Host:     req.r.Host,
+			Path:     req.r.URL.Path,
+			RawQuery: query.Encode(),
+		}
+		headers = map[string]string{"Link": fmt.Sprintf("<%s>; rel=\"next\"", next.String())}
+	}
+	return http.StatusOK, objects[start:end], headers
+}
+
+func pageParams(query url.Values, defaultSize, maxSize int) (int, int, *freshdesk.FieldError) {
+	page, perPage := 1, defaultSize
+	if value := query.Get("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return 0, 0, &freshdesk.FieldError{Field: "page", Message: "It should be a Positive Number", Code: "invalid_value"}
+		}
+		page = n
+	}
+	if value := query.Get("per_page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSize {
+			return 0, 0, &freshdesk.FieldError{Field: "per_page", Message: fmt.Sprintf("It should be a Positive Number less than or equal to %d", maxSize), Code: "invalid_value"}
+		}
+		perPage = n
+	}
+	return page, perPage, nil
+}
@@ -0,0 +1,149 @@
+package freshdesktest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+)
+
+func get(t *testing.T, server *Server, path string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("test-api-key", "X")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	return res
+}
+
+func TestPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	for i := 0; i < 25; i++ {
+		server.AddGroup(freshdesk.Group{Name: fmt.Sprintf("Group %d", i)})
+	}
+
+	res := get(t, server, "/api/v2/groups?per_page=10&page=2")
+	link := res.Header.Get("Link")
+	if !strings.Contains(link, "page=3") || !strings.Contains(link, "per_page=10") || !strings.HasSuffix(link, `rel="next"`) {
+		t.Errorf("Link = %q, want the next page", link)
+	}
+	if res := get(t, server, "/api/v2/groups?per_page=10&page=3"); res.Header.Get("Link") != "" {
+		t.Errorf("last page has Link %q", res.Header.Get("Link"))
+	}
+	for _, query := range []string{"per_page=101", "per_page=0", "page=0", "page=x"} {
+		if res := get(t, server, "/api/v2/groups?"+query); res.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s returned %d, want 400", query, res.StatusCode)
+		}
+	}
+
+	// The client follows the Link headers.
+	client := server.Client(nil)
+	groups, err := client.Groups.List(&freshdesk.ListOptions{PerPage: 10}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 25 || groups[0].Name != "Group 0" || groups[24].Name != "Group 24" {
+		t.Errorf("got %d groups", len(groups))
+	}
+	pages := 0
+	for _, req := range server.Requests() {
+		if req.Path == "/api/v2/groups" && req.Query.Get("per_page") == "10" {
+			pages++
+		}
+	}
+	// Two of them were fetched directly above.
+	if pages-2 != 3 {
+		t.Errorf("fetched %d pages, want 3", pages-2)
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	for i := 0; i < 35; i++ {
+		server.AddTicket(freshdesk.Ticket{Subject: "Paged", Status: 2})
+	}
+	if res := get(t, server, "/api/v2/search/tickets?query=%22status:2%22&page=11"); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("page 11 returned %d, want 400", res.StatusCode)
+	}
+	if res := get(t, server, "/api/v2/search/tickets?query=%22status%22"); res.StatusCode != http.StatusBadRequest {
+		t.Errorf("an invalid query returned %d, want 400", res.StatusCode)
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Faulty", Status: 2})
+	client := server.Client(&freshdesk.ClientOptions{RetryPolicy: freshdesk.NoRetryPolicy()})
+
+	server.InjectFault(Fault{Method: http.MethodPut, Status: http.StatusInternalServerError})
+	server.InjectFault(Fault{Path: "/api/v2/tickets", Status: http.StatusTooManyRequests, RetryAfter: 30, Count: 2})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Tickets.View(ticket.ID)
+		apiError := freshdesk.APIError{}
+		if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusTooManyRequests || apiError.RetryAfter != 30*time.Second {
+			t.Fatalf("attempt %d: err = %v, want a 429 with Retry-After", i+1, err)
+		}
+	}
+	// The 429 fault is used up, and the PUT fault does not match GETs.
+	if _, err := client.Tickets.View(ticket.ID); err != nil {
+		t.Errorf("after the fault was used up: %v", err)
+	}
+	if _, err := client.Groups.All(); err != nil {
+		t.Errorf("unmatched path: %v", err)
+	}
+	_, err := client.Tickets.Update(ticket.ID, freshdesk.UpdateTicket{Priority: freshdesk.Ptr(2)})
+	apiError := freshdesk.APIError{}
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusInternalServerError {
+		t.Errorf("PUT err = %v, want a 500", err)
+	}
+	server.ClearFaults()
+	if _, err := client.Tickets.Update(ticket.ID, freshdesk.UpdateTicket{Priority: freshdesk.Ptr(2)}); err != nil {
+		t.Errorf("after ClearFaults: %v", err)
+	}
+}
+
+func TestFaultLatency(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client(&freshdesk.ClientOptions{RetryPolicy: freshdesk.NoRetryPolicy()})
+	server.InjectFault(Fault{Path: "/api/v2/groups", Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Groups.AllContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("took %v, want the context deadline", elapsed)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.APIKey = "right-key"
+
+	wrong := freshdesk.Init("fake", "wrong-key", &freshdesk.ClientOptions{BaseURL: server.URL})
+	if _, err := wrong.Groups.All(); !freshdesk.IsUnauthorized(err) {
+		t.Errorf("err = %v, want unauthorized", err)
+	}
+	if _, err := server.Client(nil).Groups.All(); err != nil {
+		t.Errorf("with the server's key: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package freshdesktest
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// object is a stored resource in its JSON representation.
+type object map[string]interface{}
+
+func (o object) id() int64 {
+	return toInt64(o["id"])
+}
+
+func (o object) clone() object {
+	jsonb, _ := json.Marshal(o)
+	out := object{}
+	json.Unmarshal(jsonb, &out)
+	return out
+}
+
+// collection stores the resources of one kind, keyed by ID.
+type collection struct {
+	lastID int64
+	items  map[int64]object
+}
+
+func newCollection() *collection {
+	return &collection{
+		items: map[int64]object{},
+	}
+}
+
+// insert stores obj, assigning the next ID when it has none.
+func (c *collection) insert(obj object) object {
+	id := obj.id()
+	if id == 0 {
+		id = c.lastID + 1
+	}
+	if id > c.lastID {
+		c.lastID = id
+	}
+	obj["id"] = id
+	c.items[id] = obj
+	return obj
+}
+
+func (c *collection) get(id int64) (object, bool) {
+	obj, ok := c.items[id]
+	return obj, ok
+}
+
+func (c *collection) remove(id int64) {
+	delete(c.items, id)
+}
+
+// list returns the objects accepted by filter, ordered by ID.
+func (c *collection) list(filter func(object) bool) []object {
+	out := []object{}
+	for _, obj := range c.items {
+		if filter == nil || filter(obj) {
+			out = append(out, obj)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].id() < out[j].id() })
+	return out
+}
+
+// toObject converts any JSON-serializable value, e.g. a freshdesk.Ticket, to
+// an object. Fields of the embedded mgm.DefaultModel are dropped.
+func toObject(v interface{}) object {
+	jsonb, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	obj := object{}
+	if err := json.Unmarshal(jsonb, &obj); err != nil {
+		panic(err)
+	}
+	delete(obj, "mongo_id")
+	delete(obj, "mongo_created_at")
+	delete(obj, "mongo_updated_at")
+	return obj
+}
+
+// fromObject decodes obj into out, e.g. a *freshdesk.Ticket.
+func fromObject(obj object, out interface{}) {
+	jsonb, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(jsonb, out); err != nil {
+		panic(err)
+	}
+}
+
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case float64:
+		return int64(n)
+	case int64:
+		return n
+	case int:
+		return int64(n)
+	case json.Number:
+		i, _ := n.Int64()
+		return i
+	}
+	return 0
+}
+
+func isZero(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case float64:
+		return value == 0
+	case bool:
+		return !value
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
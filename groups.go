@@ -1,12 +1,15 @@
 package freshdesk
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 type GroupManager interface {
 	All() (GroupSlice, error)
+	AllContext(context.Context) (GroupSlice, error)
+	List(*ListOptions) *Iterator[Group]
 }
 
 type groupManager struct {
@@ -47,14 +50,21 @@ func (s GroupSlice) Print() {
 }
 
 func (manager groupManager) All() (GroupSlice, error) {
-	output := GroupSlice{}
-	_, err := manager.client.get(endpoints.groups.all, &output)
+	return manager.AllContext(context.Background())
+}
+
+func (manager groupManager) AllContext(ctx context.Context) (GroupSlice, error) {
+	output, err := manager.List(nil).All(ctx)
 	if err != nil {
 		return GroupSlice{}, err
 	}
 	return output, nil
 }
 
+func (manager groupManager) List(options *ListOptions) *Iterator[Group] {
+	return newLinkIterator[Group](manager.client, endpoints.groups.all, options, "groups.list", nil)
+}
+
 func (groups GroupSlice) SearchName(name string) (Group, error) {
 	for _, group := range groups {
 		if group.Name == name {
@@ -2,163 +2,200 @@ package freshdesk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
-	"time"
 )
 
-const httpClientTimeout = time.Second * 10
-
-func (c *ApiClient) postJSON(path string, requestBody []byte, out interface{}, expectedStatus int) error {
-	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
-	}
-	if c.logger != nil {
-		c.logger.Println(string(requestBody))
-	}
-	req, _ := http.NewRequest("POST", fmt.Sprintf("https://%s.freshdesk.com%s", c.domain, path), bytes.NewReader(requestBody))
+func (c *ApiClient) url(path string) string {
+	return c.baseURL + path
+}
 
-	req.SetBasicAuth(c.apiKey, "X")
-	req.Header.Add("Content-type", "application/json")
-	//c.logReq(req)
+// do sends an API request, retrying it according to the client's
+// RetryPolicy. The body is kept as bytes so it can be replayed on every
+// attempt.
+func (c *ApiClient) do(ctx context.Context, method, path string, body []byte, contentType string) (*http.Response, error) {
+	return c.roundTrip(ctx, c.send, method, c.url(path), path, body, contentType, true)
+}
 
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
+// roundTrip sends a request to target with send, going through the retry
+// policy, request budget, telemetry and logging. path is the target as shown
+// in logs and telemetry. The API key is only added when authorize is set.
+func (c *ApiClient) roundTrip(ctx context.Context, send RoundTripFunc, method, target, path string, body []byte, contentType string, authorize bool) (res *http.Response, err error) {
+	ctx, finish := c.telemetry.start(ctx, method, path)
+	attempts := 0
+	defer func() { finish(res, err, attempts-1) }()
+
+	c.logReq(method, path, body, contentType)
+	for attempt := 1; ; attempt++ {
+		attempts = attempt
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, target, reader)
+		if err != nil {
+			return nil, err
+		}
+		if authorize {
+			req.SetBasicAuth(c.apiKey, "X")
+		}
+		if body != nil {
+			req.Header.Add("Content-type", contentType)
+		}
 
-	if res.StatusCode != expectedStatus {
-		body, err := ioutil.ReadAll(res.Body)
-		apiError := ""
+		if err := c.budget.wait(ctx); err != nil {
+			return nil, err
+		}
+		res, err := send(req)
 		if err == nil {
-			var jsonBuffer bytes.Buffer
-			err := json.Indent(&jsonBuffer, body, "", "\t")
+			c.rateLimit.update(res.Header)
+			c.logRes(method, path, res)
+		}
+		if ctx.Err() != nil {
 			if err == nil {
-				apiError = string(jsonBuffer.Bytes())
+				res.Body.Close()
 			}
+			return nil, ctx.Err()
 		}
-		if res.StatusCode == http.StatusBadRequest && c.logger != nil {
-			if c.logger != nil {
-				c.logger.Println(apiError)
+		delay, retry := c.retryPolicy.retryDelay(retryMethod(ctx, method), attempt, res, err)
+		if !retry {
+			return res, err
+		}
+		if err == nil {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		if c.log != nil {
+			args := []interface{}{"method", method, "path", strings.SplitN(path, "?", 2)[0], "delay", delay, "attempt", attempt + 1}
+			if urlErr, ok := err.(*url.Error); ok {
+				// The URL of a url.Error may hold search queries.
+				args = append(args, "error", urlErr.Err)
+			} else if err != nil {
+				args = append(args, "error", err)
 			}
+			c.log.Warn("freshdesk: retrying request", args...)
 		}
-		return APIError{
-			fmt.Errorf("received status code %d (%d expected)", res.StatusCode, expectedStatus),
-			apiError,
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
 		}
 	}
-
-	err = json.NewDecoder(res.Body).Decode(out)
-
-	return err
 }
 
-func (c *ApiClient) put(path string, requestBody []byte, out interface{}, expectedStatus int) error {
-	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
-	}
-	if c.logger != nil {
-		c.logger.Println(string(requestBody))
-	}
-	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("https://%s.freshdesk.com%s", c.domain, path), bytes.NewReader(requestBody))
+func (c *ApiClient) postJSON(ctx context.Context, path string, requestBody []byte, out interface{}, expectedStatus int) error {
+	return c.sendBody(ctx, http.MethodPost, path, requestBody, jsonContentType, out, expectedStatus)
+}
 
-	req.SetBasicAuth(c.apiKey, "X")
-	req.Header.Add("Content-type", "application/json")
-	//c.logReq(req)
+func (c *ApiClient) put(ctx context.Context, path string, requestBody []byte, out interface{}, expectedStatus int) error {
+	return c.sendBody(ctx, http.MethodPut, path, requestBody, jsonContentType, out, expectedStatus)
+}
 
-	res, err := httpClient.Do(req)
+// sendBody sends a request body of any content type and decodes the JSON
+// response into out, unless out is nil.
+func (c *ApiClient) sendBody(ctx context.Context, method, path string, requestBody []byte, contentType string, out interface{}, expectedStatus int) error {
+	res, err := c.do(ctx, method, path, requestBody, contentType)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != expectedStatus {
-		body, err := ioutil.ReadAll(res.Body)
-		var apiError string
-		if err == nil {
-			var jsonBuffer bytes.Buffer
-			err := json.Indent(&jsonBuffer, body, "", "\t")
-			if err == nil {
-				apiError = string(jsonBuffer.Bytes())
-			}
-		}
-		if res.StatusCode == http.StatusBadRequest && c.logger != nil {
-			if c.logger != nil {
-				c.logger.Println(apiError)
-			}
-		}
-		return APIError{
-			fmt.Errorf("received status code %d (%d expected)", res.StatusCode, expectedStatus),
-			apiError,
-		}
-	}
-
-	err = json.NewDecoder(res.Body).Decode(out)
-
-	return err
-}
-
-func (c *ApiClient) get(path string, out interface{}) (http.Header, error) {
-	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
+		return c.apiError(res, expectedStatus)
 	}
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s.freshdesk.com%s", c.domain, path), nil)
-	if err != nil {
-		return nil, err
+	if out == nil {
+		return nil
 	}
 
-	req.SetBasicAuth(c.apiKey, "X")
-	if c.logger != nil {
-		c.logger.Println(path)
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response of %s %s: %w", method, path, err)
 	}
 
-	res, err := httpClient.Do(req)
+	return nil
+}
+
+func (c *ApiClient) get(ctx context.Context, path string, out interface{}) (http.Header, error) {
+	res, err := c.do(ctx, http.MethodGet, path, nil, "")
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
-	c.logRes(res)
-
-	json.NewDecoder(res.Body).Decode(out)
-
-	return res.Header, err
-}
-
-func (c *ApiClient) getNextLink(headers http.Header) string {
-	link := headers.Get("link")
-	if link != "" {
-		return strings.TrimPrefix(strings.TrimSuffix(link, ">; rel=\"next\""), fmt.Sprintf("<https://%s.freshdesk.com", c.domain))
+	if res.StatusCode != http.StatusOK {
+		return res.Header, c.apiError(res, http.StatusOK)
 	}
-	return ""
-}
 
-func (c *ApiClient) delete(path string) error {
-	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
-	}
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("https://%s.freshdesk.com%s", c.domain, path), nil)
-	if err != nil {
-		return err
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return res.Header, fmt.Errorf("decoding response of GET %s: %w", path, err)
 	}
 
-	req.SetBasicAuth(c.apiKey, "X")
-	req.Header.Add("Content-type", "application/json")
-	//c.logReq(req)
+	return res.Header, nil
+}
 
-	res, err := httpClient.Do(req)
+func (c *ApiClient) delete(ctx context.Context, path string, expectedStatus int) error {
+	res, err := c.do(ctx, http.MethodDelete, path, nil, "")
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode != 200 {
-		return fmt.Errorf("received status code %d (200 expected)", res.StatusCode)
+	if res.StatusCode != expectedStatus {
+		return c.apiError(res, expectedStatus)
 	}
 
 	return nil
 }
+
+// apiError builds the APIError returned for a response with an unexpected
+// status code. It consumes the response body.
+func (c *ApiClient) apiError(res *http.Response, expectedStatus int) error {
+	apiError := APIError{
+		StatusCode:     res.StatusCode,
+		ExpectedStatus: expectedStatus,
+		RequestID:      res.Header.Get("X-Request-Id"),
+	}
+	if res.Request != nil {
+		apiError.Method = res.Request.Method
+		apiError.Path = res.Request.URL.Path
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		apiError.RetryAfter, _ = parseRetryAfter(res.Header.Get("Retry-After"))
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil || len(body) == 0 {
+		return apiError
+	}
+	payload := struct {
+		Description string       `json:"description"`
+		Message     string       `json:"message"`
+		Errors      []FieldError `json:"errors"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiError.Description = payload.Description
+		if apiError.Description == "" {
+			apiError.Description = payload.Message
+		}
+		apiError.Errors = payload.Errors
+	}
+	var jsonBuffer bytes.Buffer
+	if err := json.Indent(&jsonBuffer, body, "", "\t"); err == nil {
+		apiError.APIError = jsonBuffer.String()
+	} else {
+		apiError.APIError = string(body)
+	}
+	if c.log != nil {
+		c.log.Warn("freshdesk: unexpected response",
+			"method", apiError.Method,
+			"path", apiError.Path,
+			"status", apiError.StatusCode,
+			"request_id", apiError.RequestID,
+			"body", c.redact(body),
+		)
+	}
+	return apiError
+}
@@ -0,0 +1,71 @@
+package freshdesk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestNotFound(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	client := server.Client(nil)
+
+	if _, err := client.Tickets.View(404); !freshdesk.IsNotFound(err) {
+		t.Errorf("GET err = %v, want not found", err)
+	}
+	if err := client.Tickets.Delete(404); !freshdesk.IsNotFound(err) {
+		t.Errorf("DELETE err = %v, want not found", err)
+	}
+}
+
+// malformed replaces the body of successful responses with invalid JSON.
+func malformed(next freshdesk.RoundTripFunc) freshdesk.RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		res, err := next(req)
+		if err == nil && res.StatusCode < 300 {
+			res.Body.Close()
+			res.Body = io.NopCloser(strings.NewReader("{not json"))
+		}
+		return res, err
+	}
+}
+
+func TestMalformedResponse(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Garbled", Status: 2})
+	client := server.Client(&freshdesk.ClientOptions{Middleware: []freshdesk.Middleware{malformed}})
+
+	calls := map[string]func() error{
+		"GET": func() error {
+			_, err := client.Tickets.View(ticket.ID)
+			return err
+		},
+		"PUT": func() error {
+			_, err := client.Tickets.Update(ticket.ID, freshdesk.UpdateTicket{Priority: freshdesk.Ptr(2)})
+			return err
+		},
+		"POST": func() error {
+			_, err := client.Tickets.Create(freshdesk.CreateTicket{Email: "a@example.com", Subject: "New", Status: 2, Priority: 1})
+			return err
+		},
+	}
+	for method, call := range calls {
+		err := call()
+		syntaxError := &json.SyntaxError{}
+		if !errors.As(err, &syntaxError) {
+			t.Errorf("%s: err = %v, want a JSON syntax error", method, err)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "decoding response of "+method+" /api/v2/tickets") {
+			t.Errorf("%s: err = %q, want it to name the request", method, err)
+		}
+	}
+}
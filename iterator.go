@@ -0,0 +1,186 @@
+package freshdesk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Done is returned by Iterator.Next once every item has been returned.
+var Done = errors.New("freshdesk: no more items")
+
+// ListOptions configures paginated list calls.
+type ListOptions struct {
+	// PerPage is the page size, between 1 and 100. Freshdesk uses 30 when
+	// zero.
+	PerPage int
+}
+
+func (options *ListOptions) apply(path string) string {
+	if options == nil || options.PerPage <= 0 {
+		return path
+	}
+	return withQuery(path, "per_page", strconv.Itoa(options.PerPage))
+}
+
+// withQuery adds a query parameter to path.
+func withQuery(path, key, value string) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + url.QueryEscape(key) + "=" + url.QueryEscape(value)
+}
+
+// Iterator streams the items of a paginated list, fetching pages as they are
+// needed. A failed fetch can be retried by calling Next again.
+type Iterator[T any] struct {
+	operation string
+	fetch     func(context.Context) ([]T, bool, error)
+	items     []T
+	more      bool
+}
+
+func newIterator[T any](operation string, fetch func(context.Context) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{
+		operation: operation,
+		fetch:     fetch,
+		more:      true,
+	}
+}
+
+// Next returns the next item, or Done when there are none left.
+func (it *Iterator[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	for len(it.items) == 0 {
+		if !it.more {
+			return zero, Done
+		}
+		items, more, err := it.fetch(withOperation(ctx, it.operation))
+		if err != nil {
+			return zero, err
+		}
+		it.items, it.more = items, more
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// ForEach calls fn for every remaining item. Returning Done from fn stops the
+// iteration without error; any other error stops it and is returned.
+func (it *Iterator[T]) ForEach(ctx context.Context, fn func(T) error) error {
+	for {
+		item, err := it.Next(ctx)
+		if err == Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			if err == Done {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// All returns every remaining item.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	out := []T{}
+	err := it.ForEach(ctx, func(item T) error {
+		out = append(out, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// newLinkIterator pages through a list endpoint by following its Link
+// headers. prepare, when set, is applied to every item, e.g. to attach the
+// client.
+func newLinkIterator[T any](client *ApiClient, path string, options *ListOptions, operation string, prepare func(*T)) *Iterator[T] {
+	next := options.apply(path)
+	return newIterator(operation, func(ctx context.Context) ([]T, bool, error) {
+		page := []T{}
+		headers, err := client.get(ctx, next, &page)
+		if err != nil {
+			return nil, true, err
+		}
+		next = client.getNextLink(headers)
+		if prepare != nil {
+			for i := range page {
+				prepare(&page[i])
+			}
+		}
+		return page, next != "", nil
+	})
+}
+
+// parseLinkHeader parses an RFC 5988 Link header into a map of relation type
+// to target URL.
+func parseLinkHeader(header string) map[string]string {
+	links := map[string]string{}
+	for header != "" {
+		start := strings.IndexByte(header, '<')
+		end := strings.IndexByte(header, '>')
+		if start < 0 || end < start {
+			break
+		}
+		target := header[start+1 : end]
+		header = header[end+1:]
+
+		// Parameters run until the next link, which starts after a comma
+		// outside of a quoted string.
+		params := header
+		header = ""
+		inQuotes := false
+		for i := 0; i < len(params); i++ {
+			if params[i] == '"' {
+				inQuotes = !inQuotes
+			} else if params[i] == ',' && !inQuotes {
+				params, header = params[:i], params[i+1:]
+				break
+			}
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				links[strings.ToLower(rel)] = target
+			}
+		}
+	}
+	return links
+}
+
+func (c *ApiClient) getNextLink(headers http.Header) string {
+	next := ""
+	for _, header := range headers.Values("Link") {
+		if target, ok := parseLinkHeader(header)["next"]; ok {
+			next = target
+		}
+	}
+	if next == "" {
+		return ""
+	}
+	// Link headers carry the account's own domain, which differs from
+	// baseURL when requests go through a proxy or custom domain.
+	u, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+	if u.IsAbs() {
+		return u.RequestURI()
+	}
+	return strings.TrimPrefix(next, c.baseURL)
+}
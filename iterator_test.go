@@ -0,0 +1,135 @@
+package freshdesk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		want   map[string]string
+	}{
+		{``, map[string]string{}},
+		{`<https://x.freshdesk.com/api/v2/groups?page=2>; rel="next"`, map[string]string{"next": "https://x.freshdesk.com/api/v2/groups?page=2"}},
+		{`</a?page=2>; rel=next`, map[string]string{"next": "/a?page=2"}},
+		{`</a?page=1>; rel="prev", </a?page=3>; rel="next"`, map[string]string{"prev": "/a?page=1", "next": "/a?page=3"}},
+		{`</a>; title="a, b"; REL="Next Last"`, map[string]string{"next": "/a", "last": "/a"}},
+		{`</a>; type="text/html"`, map[string]string{}},
+		{`garbage`, map[string]string{}},
+	}
+	for _, test := range tests {
+		if got := freshdesk.ParseLinkHeader(test.header); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseLinkHeader(%q) = %v, want %v", test.header, got, test.want)
+		}
+	}
+}
+
+func addGroups(server *freshdesktest.Server, n int) {
+	for i := 0; i < n; i++ {
+		server.AddGroup(freshdesk.Group{Name: fmt.Sprintf("Group %d", i)})
+	}
+}
+
+func TestIteratorNext(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	addGroups(server, 5)
+	client := server.Client(&freshdesk.ClientOptions{RetryPolicy: freshdesk.NoRetryPolicy()})
+	ctx := context.Background()
+
+	it := client.Groups.List(&freshdesk.ListOptions{PerPage: 2})
+	for i := 0; i < 2; i++ {
+		if group, err := it.Next(ctx); err != nil || group.Name != fmt.Sprintf("Group %d", i) {
+			t.Fatalf("item %d = %q, %v", i, group.Name, err)
+		}
+	}
+
+	// A failed page fetch is returned, and calling Next again retries it.
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/groups", Status: http.StatusServiceUnavailable, Count: 1})
+	if _, err := it.Next(ctx); err == nil {
+		t.Fatal("Next returned no error for a failed page")
+	}
+	names := []string{}
+	for {
+		group, err := it.Next(ctx)
+		if err == freshdesk.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, group.Name)
+	}
+	if want := []string{"Group 2", "Group 3", "Group 4"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("after the failure got %v, want %v", names, want)
+	}
+	if _, err := it.Next(ctx); err != freshdesk.Done {
+		t.Errorf("Next after the end = %v, want Done", err)
+	}
+}
+
+func TestIteratorForEach(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	addGroups(server, 10)
+	client := server.Client(nil)
+	ctx := context.Background()
+
+	// Returning Done stops without error or fetching further pages.
+	seen := 0
+	err := client.Groups.List(&freshdesk.ListOptions{PerPage: 3}).ForEach(ctx, func(group freshdesk.Group) error {
+		seen++
+		if seen == 4 {
+			return freshdesk.Done
+		}
+		return nil
+	})
+	if err != nil || seen != 4 {
+		t.Errorf("ForEach = %v after %d items, want nil after 4", err, seen)
+	}
+	if pages := len(server.Requests()); pages != 2 {
+		t.Errorf("fetched %d pages, want 2", pages)
+	}
+
+	stop := errors.New("stop")
+	err = client.Groups.List(nil).ForEach(ctx, func(freshdesk.Group) error { return stop })
+	if err != stop {
+		t.Errorf("ForEach = %v, want the callback's error", err)
+	}
+}
+
+func TestIteratorFollowsLinksOnOtherHosts(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	addGroups(server, 5)
+	// Freshdesk links to the account's own domain, not the proxy the client
+	// was configured with.
+	rewrite := func(next freshdesk.RoundTripFunc) freshdesk.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			res, err := next(req)
+			if err == nil {
+				link := strings.Replace(res.Header.Get("Link"), server.URL, "https://example.freshdesk.com", 1)
+				res.Header.Set("Link", link)
+			}
+			return res, err
+		}
+	}
+	client := server.Client(&freshdesk.ClientOptions{Middleware: []freshdesk.Middleware{rewrite}})
+
+	groups, err := client.Groups.List(&freshdesk.ListOptions{PerPage: 2}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 5 {
+		t.Errorf("got %d groups, want 5", len(groups))
+	}
+}
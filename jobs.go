@@ -0,0 +1,126 @@
+package freshdesk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Statuses of a background job.
+const (
+	JobStatusQueued     = "QUEUED"
+	JobStatusInProgress = "IN PROGRESS"
+	JobStatusSuccess    = "SUCCESS"
+	JobStatusPartial    = "PARTIAL"
+	JobStatusFailed     = "FAILED"
+)
+
+const (
+	jobPollInterval    = time.Second
+	maxJobPollInterval = 30 * time.Second
+)
+
+type JobManager interface {
+	View(string) (Job, error)
+	ViewContext(context.Context, string) (Job, error)
+	Wait(string) (Job, error)
+	WaitContext(context.Context, string) (Job, error)
+}
+
+type jobManager struct {
+	client *ApiClient
+}
+
+func newJobManager(client *ApiClient) jobManager {
+	return jobManager{
+		client,
+	}
+}
+
+// Job is a background job started by a bulk action.
+type Job struct {
+	ID        string      `json:"id"`
+	Status    string      `json:"status"`
+	CreatedAt *time.Time  `json:"created_at"`
+	UpdatedAt *time.Time  `json:"updated_at"`
+	Data      []JobResult `json:"data"`
+}
+
+// JobResult is the outcome of a job for one record.
+type JobResult struct {
+	ID      int64       `json:"id"`
+	Success bool        `json:"success"`
+	Message string      `json:"message,omitempty"`
+	Errors  interface{} `json:"errors,omitempty"`
+}
+
+// Done reports whether the job has finished, successfully or not.
+func (job Job) Done() bool {
+	return job.Status != "" && job.Status != JobStatusQueued && job.Status != JobStatusInProgress
+}
+
+// Succeeded returns the IDs of the records the job was applied to.
+func (job Job) Succeeded() []int64 {
+	ids := []int64{}
+	for _, result := range job.Data {
+		if result.Success {
+			ids = append(ids, result.ID)
+		}
+	}
+	return ids
+}
+
+// Failed returns the results of the records the job could not be applied to.
+func (job Job) Failed() []JobResult {
+	failed := []JobResult{}
+	for _, result := range job.Data {
+		if !result.Success {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func (job Job) Print() {
+	fmt.Printf("%s: %s, %d succeeded, %d failed\n", job.ID, job.Status, len(job.Succeeded()), len(job.Failed()))
+}
+
+func (manager jobManager) View(id string) (Job, error) {
+	return manager.ViewContext(context.Background(), id)
+}
+
+func (manager jobManager) ViewContext(ctx context.Context, id string) (Job, error) {
+	ctx = withOperation(ctx, "jobs.view")
+	output := Job{}
+	_, err := manager.client.get(ctx, endpoints.jobs.view(id), &output)
+	if err != nil {
+		return Job{}, err
+	}
+	return output, nil
+}
+
+func (manager jobManager) Wait(id string) (Job, error) {
+	return manager.WaitContext(context.Background(), id)
+}
+
+// WaitContext polls the job, backing off from one to 30 seconds between
+// polls, until it is done or ctx ends.
+func (manager jobManager) WaitContext(ctx context.Context, id string) (Job, error) {
+	delay := jobPollInterval
+	for {
+		job, err := manager.ViewContext(ctx, id)
+		if err != nil {
+			return Job{}, err
+		}
+		if job.Done() {
+			return job, nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return job, err
+		}
+		delay *= 2
+		if delay > maxJobPollInterval {
+			delay = maxJobPollInterval
+		}
+	}
+}
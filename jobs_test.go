@@ -0,0 +1,112 @@
+package freshdesk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestBulkActions(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	first := server.AddTicket(freshdesk.Ticket{Subject: "First", Status: 2, Priority: 1})
+	second := server.AddTicket(freshdesk.Ticket{Subject: "Second", Status: 2, Priority: 1})
+	client := server.Client(nil)
+
+	jobID, err := client.Tickets.BulkUpdate([]int64{first.ID, second.ID, 999}, freshdesk.UpdateTicket{Status: freshdesk.Ptr(4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobID == "" {
+		t.Fatal("BulkUpdate returned no job ID")
+	}
+	job, err := client.Jobs.Wait(jobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.ID != jobID || job.Status != freshdesk.JobStatusPartial || !job.Done() {
+		t.Errorf("job %s is %s, want %s %s", job.ID, job.Status, jobID, freshdesk.JobStatusPartial)
+	}
+	if !reflect.DeepEqual(job.Succeeded(), []int64{first.ID, second.ID}) {
+		t.Errorf("succeeded = %v", job.Succeeded())
+	}
+	if failed := job.Failed(); len(failed) != 1 || failed[0].ID != 999 || failed[0].Message != "Ticket not found" {
+		t.Errorf("failed = %+v, want ticket 999 not found", failed)
+	}
+	if ticket, _ := server.Ticket(second.ID); ticket.Status != 4 {
+		t.Errorf("ticket status %d, want 4", ticket.Status)
+	}
+
+	jobID, err = client.Tickets.BulkDelete([]int64{first.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err = client.Jobs.Wait(jobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != freshdesk.JobStatusSuccess || len(job.Failed()) != 0 {
+		t.Errorf("job is %s with failures %+v", job.Status, job.Failed())
+	}
+	if ticket, _ := server.Ticket(first.ID); !ticket.Deleted {
+		t.Error("ticket was not deleted")
+	}
+}
+
+// jobServer serves a job that stays in progress for the given number of
+// polls.
+func jobServer(polls *int32, inProgress int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := freshdesk.JobStatusSuccess
+		if atomic.AddInt32(polls, 1) <= inProgress {
+			status = freshdesk.JobStatusInProgress
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"job-1","status":"` + status + `","data":[{"id":1,"success":true}]}`))
+	}))
+}
+
+func TestJobsWaitPolls(t *testing.T) {
+	var polls int32
+	server := jobServer(&polls, 1)
+	defer server.Close()
+	client := freshdesk.Init("fake", "key", &freshdesk.ClientOptions{BaseURL: server.URL})
+
+	job, err := client.Jobs.Wait("job-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != freshdesk.JobStatusSuccess || polls != 2 {
+		t.Errorf("job is %s after %d polls, want %s after 2", job.Status, polls, freshdesk.JobStatusSuccess)
+	}
+}
+
+func TestJobsWaitStopsWithContext(t *testing.T) {
+	var polls int32
+	server := jobServer(&polls, 1000)
+	defer server.Close()
+	client := freshdesk.Init("fake", "key", &freshdesk.ClientOptions{BaseURL: server.URL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	job, err := client.Jobs.WaitContext(ctx, "job-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Wait returned after %v, want it to stop with the context", elapsed)
+	}
+	// The last state seen is returned along with the error.
+	if job.Status != freshdesk.JobStatusInProgress || polls != 1 {
+		t.Errorf("job is %s after %d polls", job.Status, polls)
+	}
+}
@@ -0,0 +1,149 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Logger is a leveled, structured logger taking alternating key/value pairs
+// after the message. *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// DefaultRedactFields are the JSON fields replaced in logged request and
+// response bodies when ClientOptions.RedactFields is nil.
+var DefaultRedactFields = []string{
+	"name",
+	"email",
+	"other_emails",
+	"phone",
+	"mobile",
+	"address",
+	"twitter_id",
+	"facebook_id",
+	"body",
+	"body_text",
+	"description",
+	"description_text",
+	"from_email",
+	"to_emails",
+	"cc_emails",
+	"bcc_emails",
+	"fwd_emails",
+	"reply_cc_emails",
+	"support_email",
+}
+
+const redacted = "[REDACTED]"
+
+// stdLogger adapts a *log.Logger to the Logger interface.
+type stdLogger struct {
+	logger *log.Logger
+}
+
+func (l stdLogger) Debug(msg string, args ...interface{}) { l.print("DEBUG", msg, args) }
+func (l stdLogger) Info(msg string, args ...interface{})  { l.print("INFO", msg, args) }
+func (l stdLogger) Warn(msg string, args ...interface{})  { l.print("WARN", msg, args) }
+func (l stdLogger) Error(msg string, args ...interface{}) { l.print("ERROR", msg, args) }
+
+func (l stdLogger) print(level, msg string, args []interface{}) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%q", args[i], fmt.Sprint(args[i+1]))
+	}
+	l.logger.Println(b.String())
+}
+
+func newRedactFields(fields []string) map[string]bool {
+	if fields == nil {
+		fields = DefaultRedactFields
+	}
+	set := map[string]bool{}
+	for _, field := range fields {
+		set[strings.ToLower(field)] = true
+	}
+	return set
+}
+
+// redact returns body with the values of the client's redacted fields
+// replaced, at any depth. Bodies that are not JSON are not logged at all when
+// redaction is enabled, as there is no way to tell what they contain.
+func (c *ApiClient) redact(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if len(c.redactFields) == 0 {
+		return string(body)
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("[%d bytes of non-JSON content]", len(body))
+	}
+	jsonb, err := json.Marshal(redactValue(value, c.redactFields))
+	if err != nil {
+		return redacted
+	}
+	return string(jsonb)
+}
+
+func redactValue(value interface{}, fields map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if fields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(inner, fields)
+		}
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = redactValue(inner, fields)
+		}
+	}
+	return value
+}
+
+func (c *ApiClient) logErr(err error) {
+	if err != nil && c.log != nil {
+		c.log.Error("freshdesk: " + err.Error())
+	}
+}
+
+// logReq logs an outgoing request. The query string is left out as search
+// queries routinely contain emails and phone numbers, and the authorization
+// header is never logged.
+func (c *ApiClient) logReq(method, path string, body []byte, contentType string) {
+	if c.log == nil {
+		return
+	}
+	args := []interface{}{"method", method, "path", strings.SplitN(path, "?", 2)[0]}
+	if body != nil && contentType == jsonContentType {
+		args = append(args, "body", c.redact(body))
+	} else if body != nil {
+		mediaType := strings.SplitN(contentType, ";", 2)[0]
+		args = append(args, "body", fmt.Sprintf("[%d bytes of %s]", len(body), mediaType))
+	}
+	c.log.Debug("freshdesk: request", args...)
+}
+
+func (c *ApiClient) logRes(method, path string, res *http.Response) {
+	if c.log == nil {
+		return
+	}
+	c.log.Debug("freshdesk: response",
+		"method", method,
+		"path", strings.SplitN(path, "?", 2)[0],
+		"status", res.StatusCode,
+	)
+}
@@ -0,0 +1,180 @@
+package freshdesk_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
+)
+
+// recordingLogger keeps every log line, at every level, as text.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.record("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.record("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record("ERROR", msg, args) }
+
+func (l *recordingLogger) record(level, msg string, args []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprint(level, " ", msg, " ", args))
+}
+
+// matching returns the lines containing msg.
+func (l *recordingLogger) matching(msg string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var lines []string
+	for _, line := range l.lines {
+		if strings.Contains(line, msg) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestLoggingNeverShowsCredentials(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.APIKey = "s3cret-api-key"
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/groups", Status: http.StatusServiceUnavailable, Count: 1})
+
+	structured := &recordingLogger{}
+	var std bytes.Buffer
+	for _, options := range []*freshdesk.ClientOptions{
+		{StructuredLogger: structured, RetryPolicy: fastRetries()},
+		{Logger: log.New(&std, "", 0), RetryPolicy: fastRetries()},
+	} {
+		client := server.Client(options)
+		if _, err := client.Groups.All(); err != nil {
+			t.Fatal(err)
+		}
+		client.Tickets.Create(freshdesk.CreateTicket{Subject: "No requester"})
+		client.Tickets.View(404)
+	}
+
+	basic := base64.StdEncoding.EncodeToString([]byte("s3cret-api-key:X"))
+	logs := strings.Join(structured.matching(""), "\n") + "\n" + std.String()
+	for _, secret := range []string{"s3cret-api-key", basic, "Authorization", "Basic "} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("logs contain %q:\n%s", secret, logs)
+		}
+	}
+	for _, level := range []string{"DEBUG", "INFO", "WARN"} {
+		if !strings.Contains(logs, level) {
+			t.Errorf("no %s lines logged:\n%s", level, logs)
+		}
+	}
+}
+
+func TestLoggingRedactsBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"code":"duplicate_value","name":"Ada Lovelace","contact":{"email":"ada@example.com","phone":5551234}}`))
+	}))
+	defer server.Close()
+
+	contact := &freshdesk.User{Name: "Ada Lovelace", Email: "ada@example.com", Phone: 5551234}
+	pii := []string{"Ada Lovelace", "ada@example.com", "5551234"}
+
+	logger := &recordingLogger{}
+	client := freshdesk.Init("fake", "key", &freshdesk.ClientOptions{BaseURL: server.URL, StructuredLogger: logger})
+	if _, err := client.Contacts.Create(contact); err == nil {
+		t.Fatal("expected an error")
+	}
+	request, response := logger.matching("freshdesk: request"), logger.matching("unexpected response")
+	if len(request) != 1 || len(response) != 1 {
+		t.Fatalf("got %d request and %d error lines, want 1 of each", len(request), len(response))
+	}
+	for _, line := range []string{request[0], response[0]} {
+		for _, value := range pii {
+			if strings.Contains(line, value) {
+				t.Errorf("%q is logged: %s", value, line)
+			}
+		}
+		if !strings.Contains(line, "[REDACTED]") {
+			t.Errorf("no redacted values in %s", line)
+		}
+	}
+	if !strings.Contains(response[0], "duplicate_value") {
+		t.Errorf("fields outside RedactFields are masked: %s", response[0])
+	}
+
+	// An empty RedactFields logs bodies as they are.
+	logger = &recordingLogger{}
+	client = freshdesk.Init("fake", "key", &freshdesk.ClientOptions{BaseURL: server.URL, StructuredLogger: logger, RedactFields: []string{}})
+	client.Contacts.Create(contact)
+	for _, line := range append(logger.matching("freshdesk: request"), logger.matching("unexpected response")...) {
+		for _, value := range pii {
+			if !strings.Contains(line, value) {
+				t.Errorf("%q is not logged with redaction disabled: %s", value, line)
+			}
+		}
+	}
+}
+
+func TestLoggingNonJSONBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>ada@example.com</html>"))
+	}))
+	defer server.Close()
+	logger := &recordingLogger{}
+	client := freshdesk.Init("fake", "key", &freshdesk.ClientOptions{
+		BaseURL:          server.URL,
+		StructuredLogger: logger,
+		RetryPolicy:      freshdesk.NoRetryPolicy(),
+	})
+
+	client.Tickets.Create(freshdesk.CreateTicket{
+		Email: "ada@example.com", Subject: "Logs", Description: "Attached", Status: 2, Priority: 1,
+		Attachments: []freshdesk.File{{Name: "server.log", Content: strings.NewReader("ada@example.com")}},
+	})
+
+	logs := strings.Join(logger.matching("freshdesk:"), "\n")
+	if strings.Contains(logs, "ada@example.com") {
+		t.Errorf("non-JSON body content is logged:\n%s", logs)
+	}
+	for _, placeholder := range []string{" bytes of multipart/form-data]", " bytes of non-JSON content]"} {
+		if !strings.Contains(logs, placeholder) {
+			t.Errorf("logs lack the %q placeholder:\n%s", placeholder, logs)
+		}
+	}
+}
+
+func TestLoggingRetriesOmitQuery(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/search/contacts", Status: http.StatusServiceUnavailable, Count: 1})
+	logger := &recordingLogger{}
+	client := server.Client(&freshdesk.ClientOptions{StructuredLogger: logger, RetryPolicy: fastRetries()})
+
+	if _, err := client.Contacts.Search(querybuilder.Parameter("email").Is("ada@example.com")); err != nil {
+		t.Fatal(err)
+	}
+
+	retries := logger.matching("freshdesk: retrying request")
+	if len(retries) != 1 {
+		t.Fatalf("got %d retry lines, want 1", len(retries))
+	}
+	if strings.Contains(retries[0], "?") || strings.Contains(retries[0], "ada") {
+		t.Errorf("retry line contains the query: %s", retries[0])
+	}
+	if !strings.Contains(retries[0], "/api/v2/search/contacts") {
+		t.Errorf("retry line lacks the path: %s", retries[0])
+	}
+}
@@ -1,28 +1,84 @@
 package freshdesk
 
 import (
-	"bytes"
-	"encoding/json"
-	"io/ioutil"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const defaultHTTPClientTimeout = time.Second * 10
+
 type ApiClient struct {
-	domain      string
-	apiKey      string
-	logger      *log.Logger
-	Agents      AgentManager
-	Companies   CompanyManager
-	Contacts    UserManager
-	Groups      GroupManager
-	SLAPolicies SLAPolicyManager
-	Solutions   SolutionManager
-	Tickets     TicketManager
+	domain              string
+	apiKey              string
+	baseURL             string
+	httpClient          *http.Client
+	send                RoundTripFunc
+	download            RoundTripFunc
+	retryPolicy         *RetryPolicy
+	rateLimit           *rateLimitTracker
+	budget              *requestBudget
+	log                 Logger
+	redactFields        map[string]bool
+	telemetry           *telemetry
+	Admin               AdminManager
+	Agents              AgentManager
+	Attachments         AttachmentManager
+	Companies           CompanyManager
+	Contacts            UserManager
+	Conversations       ConversationManager
+	Groups              GroupManager
+	Jobs                JobManager
+	SatisfactionRatings SatisfactionRatingManager
+	SLAPolicies         SLAPolicyManager
+	Solutions           SolutionManager
+	Surveys             SurveyManager
+	Tickets             TicketManager
+	TimeEntries         TimeEntryManager
 }
 
 type ClientOptions struct {
+	// Logger receives the client's logs at every level. StructuredLogger
+	// takes precedence when both are set.
 	Logger *log.Logger
+	// StructuredLogger is a leveled logger, e.g. a *slog.Logger.
+	StructuredLogger Logger
+	// RedactFields lists the JSON fields whose values are replaced in logged
+	// bodies. DefaultRedactFields is used when nil; an empty slice disables
+	// redaction.
+	RedactFields []string
+	// BaseURL overrides the default https://<domain>.freshdesk.com, e.g. for
+	// custom domains, proxies or a local test server.
+	BaseURL string
+	// HTTPClient is used for every request when set. Transport and Timeout
+	// are ignored in that case.
+	HTTPClient *http.Client
+	// Transport is the RoundTripper used by the default http.Client.
+	Transport http.RoundTripper
+	// Timeout of the default http.Client, 10 seconds when zero.
+	Timeout time.Duration
+	// RetryPolicy defaults to DefaultRetryPolicy(). Use NoRetryPolicy() to
+	// disable retries.
+	RetryPolicy *RetryPolicy
+	// RequestsPerMinute enables a client-side token bucket that throttles
+	// outgoing requests, so bulk jobs stay below the account's rate limit.
+	RequestsPerMinute int
+	// Burst is the number of requests that may be sent at once before the
+	// bucket starts throttling, 1 when zero.
+	Burst int
+	// Middleware wraps every request sent by the client, in order: the first
+	// entry sees the request first and the response last.
+	Middleware []Middleware
+	// TracerProvider enables a span per API call, named after the logical
+	// operation, e.g. freshdesk.tickets.view.
+	TracerProvider trace.TracerProvider
+	// MeterProvider enables latency, error and rate-limit metrics.
+	MeterProvider metric.MeterProvider
 }
 
 func EmptyOptions() *ClientOptions {
@@ -32,58 +88,74 @@ func EmptyOptions() *ClientOptions {
 // Init initializes the package
 func Init(domain, apiKey string, options *ClientOptions) ApiClient {
 	client := ApiClient{
-		domain: domain,
-		apiKey: apiKey,
+		domain:    domain,
+		apiKey:    apiKey,
+		baseURL:   fmt.Sprintf("https://%s.freshdesk.com", domain),
+		rateLimit: &rateLimitTracker{},
 	}
+	timeout := defaultHTTPClientTimeout
+	var transport http.RoundTripper
+	var middleware []Middleware
+	var redactFields []string
+	var tracerProvider trace.TracerProvider
+	var meterProvider metric.MeterProvider
 	if options != nil {
-		client.logger = options.Logger
+		if options.Logger != nil {
+			client.log = stdLogger{options.Logger}
+		}
+		if options.StructuredLogger != nil {
+			client.log = options.StructuredLogger
+		}
+		redactFields = options.RedactFields
+		if options.BaseURL != "" {
+			client.baseURL = strings.TrimSuffix(options.BaseURL, "/")
+		}
+		if options.Timeout > 0 {
+			timeout = options.Timeout
+		}
+		transport = options.Transport
+		client.httpClient = options.HTTPClient
+		client.retryPolicy = options.RetryPolicy
+		client.budget = newRequestBudget(options.RequestsPerMinute, options.Burst)
+		middleware = options.Middleware
+		tracerProvider = options.TracerProvider
+		meterProvider = options.MeterProvider
 	}
-	if client.logger != nil {
-		client.logger.Println("Freshdesk Client initializing... Domain =", domain, "authorization =", apiKey)
+	if client.retryPolicy == nil {
+		client.retryPolicy = DefaultRetryPolicy()
 	}
+	if client.httpClient == nil {
+		client.httpClient = &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		}
+	}
+	client.send = chainMiddleware(client.httpClient.Do, middleware)
+	// Attachment downloads stream bodies of any size, which the client's
+	// Timeout would cut off; they are bounded by their context instead.
+	downloadClient := *client.httpClient
+	downloadClient.Timeout = 0
+	client.download = chainMiddleware(downloadClient.Do, middleware)
+	client.redactFields = newRedactFields(redactFields)
+	if client.log != nil {
+		client.log.Info("freshdesk: client initializing", "domain", domain, "base_url", client.baseURL)
+	}
+	var err error
+	client.telemetry, err = newTelemetry(tracerProvider, meterProvider, client.rateLimit)
+	client.logErr(err)
+	client.Admin = newAdminManager(&client)
 	client.Agents = newAgentManager(&client)
+	client.Attachments = newAttachmentManager(&client)
 	client.Companies = newCompanyManager(&client)
 	client.Contacts = newUserManager(&client)
+	client.Conversations = newConversationManager(&client)
 	client.Groups = newGroupManager(&client)
+	client.Jobs = newJobManager(&client)
+	client.SatisfactionRatings = newSatisfactionRatingManager(&client)
 	client.SLAPolicies = newSLAPolicyManager(&client)
 	client.Solutions = newSolutionManager(&client)
+	client.Surveys = newSurveyManager(&client)
 	client.Tickets = newTicketManager(&client)
+	client.TimeEntries = newTimeEntryManager(&client)
 	return client
 }
-
-func (client ApiClient) logErr(err error) {
-	if err != nil && client.logger != nil {
-		client.logger.Println(err.Error())
-	}
-}
-
-func (client ApiClient) logReq(req *http.Request) {
-	if client.logger != nil {
-		client.logger.Println("Headers")
-		for k, v := range req.Header {
-			client.logger.Printf("%s: %s\n", k, v)
-		}
-		client.logger.Println("URL:", req.URL)
-		if req.Body != nil {
-			body, _ := ioutil.ReadAll(req.Body)
-			var jsonBuffer bytes.Buffer
-			json.Indent(&jsonBuffer, body, "", "\t")
-			client.logger.Println(string(jsonBuffer.Bytes()))
-		}
-	}
-}
-
-func (client ApiClient) logRes(res *http.Response) {
-	if client.logger != nil {
-		client.logger.Println("Status:", res.StatusCode)
-		if res.StatusCode != 200 {
-			body, err := ioutil.ReadAll(res.Body)
-			if err != nil {
-				client.logger.Println(err.Error())
-			}
-			var jsonBuffer bytes.Buffer
-			json.Indent(&jsonBuffer, body, "", "\t")
-			client.logger.Println(string(jsonBuffer.Bytes()))
-		}
-	}
-}
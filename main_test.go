@@ -0,0 +1,83 @@
+package freshdesk_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+// recordingTransport answers every request with an empty list and keeps the
+// requests it saw.
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (transport *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.requests = append(transport.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("[]")),
+		Request:    req,
+	}, nil
+}
+
+func TestClientOptions(t *testing.T) {
+	// The default base URL is the account's domain.
+	transport := &recordingTransport{}
+	client := freshdesk.Init("acme", "key", &freshdesk.ClientOptions{Transport: transport})
+	if _, err := client.Groups.All(); err != nil {
+		t.Fatal(err)
+	}
+	if len(transport.requests) != 1 || transport.requests[0].URL.String() != "https://acme.freshdesk.com/api/v2/groups" {
+		t.Fatalf("requests = %v, want one to https://acme.freshdesk.com/api/v2/groups", transport.requests)
+	}
+	if user, password, _ := transport.requests[0].BasicAuth(); user != "key" || password != "X" {
+		t.Errorf("basic auth = %q, %q", user, password)
+	}
+
+	// BaseURL replaces it, trailing slash or not.
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddGroup(freshdesk.Group{Name: "Support"})
+	client = freshdesk.Init("acme", "test-api-key", &freshdesk.ClientOptions{BaseURL: server.URL + "/"})
+	if groups, err := client.Groups.All(); err != nil || len(groups) != 1 {
+		t.Errorf("through BaseURL got %v, %v", groups, err)
+	}
+
+	// HTTPClient is used as is; Transport and Timeout are ignored.
+	custom := &recordingTransport{}
+	unused := &recordingTransport{}
+	client = freshdesk.Init("acme", "key", &freshdesk.ClientOptions{
+		HTTPClient: &http.Client{Transport: custom},
+		Transport:  unused,
+		Timeout:    time.Nanosecond,
+	})
+	if _, err := client.Groups.All(); err != nil {
+		t.Fatal(err)
+	}
+	if len(custom.requests) != 1 || len(unused.requests) != 0 {
+		t.Errorf("HTTPClient sent %d requests and Transport %d, want 1 and 0", len(custom.requests), len(unused.requests))
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/groups", Latency: time.Second})
+	client := server.Client(&freshdesk.ClientOptions{Timeout: 50 * time.Millisecond, RetryPolicy: freshdesk.NoRetryPolicy()})
+
+	start := time.Now()
+	_, err := client.Groups.All()
+	if err == nil || !strings.Contains(err.Error(), "Client.Timeout") {
+		t.Errorf("err = %v, want the client timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("took %v, want about 50ms", elapsed)
+	}
+}
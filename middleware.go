@@ -0,0 +1,24 @@
+package freshdesk
+
+import "net/http"
+
+// RoundTripFunc sends a single HTTP request and returns its response.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the function sending requests to Freshdesk. It sees every
+// outgoing request, authorization header included, and the resulting response
+// or error. Retried requests go through the middleware once per attempt.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chainMiddleware wraps send so that the first middleware is the outermost.
+func chainMiddleware(send RoundTripFunc, middleware []Middleware) RoundTripFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		send = middleware[i](send)
+	}
+	return send
+}
@@ -0,0 +1,110 @@
+package freshdesk_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+// tracing records when a request enters and its response leaves the
+// middleware named name.
+func tracing(name string, trace *[]string) freshdesk.Middleware {
+	return func(next freshdesk.RoundTripFunc) freshdesk.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			*trace = append(*trace, name+" request "+req.URL.Path)
+			res, err := next(req)
+			status := "error"
+			if res != nil {
+				status = fmt.Sprint(res.StatusCode)
+			}
+			*trace = append(*trace, name+" response "+status)
+			return res, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddGroup(freshdesk.Group{Name: "Support"})
+
+	var trace []string
+	client := server.Client(&freshdesk.ClientOptions{
+		Middleware: []freshdesk.Middleware{tracing("outer", &trace), tracing("inner", &trace)},
+	})
+	if _, err := client.Groups.All(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"outer request /api/v2/groups",
+		"inner request /api/v2/groups",
+		"inner response 200",
+		"outer response 200",
+	}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %q, want %q", trace, want)
+	}
+}
+
+func TestMiddlewareSeesEveryAttempt(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddGroup(freshdesk.Group{Name: "Support"})
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/groups", Status: http.StatusServiceUnavailable, Count: 2})
+
+	var trace []string
+	client := server.Client(&freshdesk.ClientOptions{
+		RetryPolicy: fastRetries(),
+		Middleware:  []freshdesk.Middleware{tracing("mw", &trace)},
+	})
+	if _, err := client.Groups.All(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"mw request /api/v2/groups", "mw response 503",
+		"mw request /api/v2/groups", "mw response 503",
+		"mw request /api/v2/groups", "mw response 200",
+	}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %q, want %q", trace, want)
+	}
+}
+
+func TestMiddlewareWrapsDownloads(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+
+	var trace []string
+	client := server.Client(&freshdesk.ClientOptions{
+		Middleware: []freshdesk.Middleware{tracing("mw", &trace)},
+	})
+	ticket, err := client.Tickets.Create(freshdesk.CreateTicket{
+		Email: "ada@example.com", Subject: "Logs", Description: "Attached", Status: 2, Priority: 1,
+		Attachments: []freshdesk.File{{Name: "server.log", Content: strings.NewReader("disk full")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trace = nil
+	content, err := client.Attachments.Download(ticket.Attachments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer content.Close()
+	if _, err := io.ReadAll(content); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(trace) != 2 || !strings.HasPrefix(trace[0], "mw request /attachments/") || trace[1] != "mw response 200" {
+		t.Errorf("trace = %q, want the download to pass through the middleware", trace)
+	}
+}
@@ -0,0 +1,106 @@
+package freshdesk
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the account's rate-limit state as reported by the
+// X-Ratelimit-* headers of the most recent response.
+type RateLimit struct {
+	Total              int
+	Remaining          int
+	UsedCurrentRequest int
+	UpdatedAt          time.Time
+}
+
+type rateLimitTracker struct {
+	mu    sync.Mutex
+	state RateLimit
+}
+
+func (tracker *rateLimitTracker) update(headers http.Header) {
+	total, err := strconv.Atoi(headers.Get("X-Ratelimit-Total"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(headers.Get("X-Ratelimit-Remaining"))
+	used, _ := strconv.Atoi(headers.Get("X-Ratelimit-Used-CurrentRequest"))
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.state = RateLimit{
+		Total:              total,
+		Remaining:          remaining,
+		UsedCurrentRequest: used,
+		UpdatedAt:          time.Now(),
+	}
+}
+
+func (tracker *rateLimitTracker) get() RateLimit {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return tracker.state
+}
+
+// RateLimit returns the latest rate-limit state seen by the client. It is the
+// zero value until a response carrying the X-Ratelimit-* headers arrives.
+func (c *ApiClient) RateLimit() RateLimit {
+	return c.rateLimit.get()
+}
+
+// requestBudget is a token bucket throttling outgoing requests on the client
+// side, so that bulk jobs stay below the account's per-minute limit.
+type requestBudget struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRequestBudget(requestsPerMinute, burst int) *requestBudget {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &requestBudget{
+		interval: time.Minute / time.Duration(requestsPerMinute),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a request may be sent. A nil budget never blocks.
+func (budget *requestBudget) wait(ctx context.Context) error {
+	if budget == nil {
+		return nil
+	}
+	budget.mu.Lock()
+	now := time.Now()
+	budget.tokens += float64(now.Sub(budget.last)) / float64(budget.interval)
+	if budget.tokens > budget.burst {
+		budget.tokens = budget.burst
+	}
+	budget.last = now
+	budget.tokens--
+	delay := time.Duration(0)
+	if budget.tokens < 0 {
+		delay = time.Duration(-budget.tokens * float64(budget.interval))
+	}
+	budget.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		budget.mu.Lock()
+		budget.tokens++
+		budget.mu.Unlock()
+		return err
+	}
+	return nil
+}
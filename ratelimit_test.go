@@ -0,0 +1,93 @@
+package freshdesk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func timeWait(t *testing.T, budget *freshdesk.RequestBudget) time.Duration {
+	t.Helper()
+	start := time.Now()
+	if err := budget.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return time.Since(start)
+}
+
+func TestRequestBudgetWait(t *testing.T) {
+	// One request every 100ms, two at once.
+	budget := freshdesk.NewRequestBudget(600, 2)
+	for i := 0; i < 2; i++ {
+		if waited := timeWait(t, budget); waited > 20*time.Millisecond {
+			t.Errorf("request %d of the burst waited %v", i+1, waited)
+		}
+	}
+	if waited := timeWait(t, budget); waited < 80*time.Millisecond || waited > 200*time.Millisecond {
+		t.Errorf("request after the burst waited %v, want about 100ms", waited)
+	}
+
+	// A cancelled wait gives its token back.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := budget.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if waited := timeWait(t, budget); waited > 200*time.Millisecond {
+		t.Errorf("request after a cancelled wait waited %v, want at most 100ms", waited)
+	}
+
+	// The bucket refills up to the burst while idle.
+	time.Sleep(300 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if waited := timeWait(t, budget); waited > 20*time.Millisecond {
+			t.Errorf("request %d after idling waited %v", i+1, waited)
+		}
+	}
+}
+
+func TestRequestBudgetDisabled(t *testing.T) {
+	budget := freshdesk.NewRequestBudget(0, 5)
+	for i := 0; i < 100; i++ {
+		if waited := timeWait(t, budget); waited > 20*time.Millisecond {
+			t.Fatalf("a disabled budget waited %v", waited)
+		}
+	}
+}
+
+func TestRequestBudgetThrottlesClient(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	client := server.Client(&freshdesk.ClientOptions{RequestsPerMinute: 1200, Burst: 1})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := client.Groups.All(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first request goes out at once, the next four 50ms apart.
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("5 requests took %v, want at least 200ms", elapsed)
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	client := server.Client(&freshdesk.ClientOptions{Middleware: []freshdesk.Middleware{rateLimitHeaders("123")}})
+	if state := client.RateLimit(); !state.UpdatedAt.IsZero() {
+		t.Errorf("state before any response = %+v", state)
+	}
+	if _, err := client.Groups.All(); err != nil {
+		t.Fatal(err)
+	}
+	state := client.RateLimit()
+	if state.Total != 700 || state.Remaining != 123 || state.UsedCurrentRequest != 1 || state.UpdatedAt.IsZero() {
+		t.Errorf("state = %+v", state)
+	}
+}
@@ -0,0 +1,150 @@
+package freshdesk
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// A 429 response is retried for every method once the Retry-After delay has
+// passed, as Freshdesk rejects those requests before processing them. 5xx
+// responses and network errors are only retried for the listed Methods,
+// using jittered exponential backoff, and never for requests that are unsafe
+// to repeat, such as ticket merges and timer toggles.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// Methods that are safe to retry on 5xx responses and network errors.
+	Methods []string
+	// BaseDelay is the backoff before the first retry, doubled on every
+	// following attempt.
+	BaseDelay time.Duration
+	// MaxWait caps the delay between two attempts. A 429 whose Retry-After
+	// exceeds MaxWait is returned to the caller instead of being retried.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy is used when ClientOptions.RetryPolicy is nil.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		Methods:     []string{http.MethodGet, http.MethodPut, http.MethodDelete},
+		BaseDelay:   time.Millisecond * 500,
+		MaxWait:     time.Minute,
+	}
+}
+
+// NoRetryPolicy makes every request a single attempt.
+func NoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 1,
+	}
+}
+
+func (policy *RetryPolicy) retriesMethod(method string) bool {
+	for _, m := range policy.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the jittered delay before retry number attempt (starting at 1).
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := policy.BaseDelay
+	if delay <= 0 {
+		return 0
+	}
+	for i := 1; i < attempt && (policy.MaxWait <= 0 || delay < policy.MaxWait); i++ {
+		delay *= 2
+	}
+	if policy.MaxWait > 0 && delay > policy.MaxWait {
+		delay = policy.MaxWait
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryDelay decides whether the outcome of an attempt should be retried and
+// how long to wait before doing so.
+func (policy *RetryPolicy) retryDelay(method string, attempt int, res *http.Response, err error) (time.Duration, bool) {
+	if attempt >= policy.MaxAttempts {
+		return 0, false
+	}
+	switch {
+	case err != nil:
+		if !policy.retriesMethod(method) {
+			return 0, false
+		}
+		return policy.backoff(attempt), true
+	case res.StatusCode == http.StatusTooManyRequests:
+		delay, ok := parseRetryAfter(res.Header.Get("Retry-After"))
+		if !ok {
+			delay = policy.backoff(attempt)
+		}
+		if policy.MaxWait > 0 && delay > policy.MaxWait {
+			return 0, false
+		}
+		return delay, true
+	case res.StatusCode >= 500:
+		if !policy.retriesMethod(method) {
+			return 0, false
+		}
+		return policy.backoff(attempt), true
+	}
+	return 0, false
+}
+
+type sendOnceKey struct{}
+
+// sendOnce marks the requests sent with ctx as unsafe to repeat, such as
+// merging tickets. A 5xx response or network error may follow a request
+// that was applied, so these are only retried on 429, which Freshdesk
+// returns before processing a request.
+func sendOnce(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sendOnceKey{}, true)
+}
+
+// retryMethod returns the method a RetryPolicy checks for ctx: none for
+// requests marked with sendOnce.
+func retryMethod(ctx context.Context, method string) string {
+	if ctx.Value(sendOnceKey{}) != nil {
+		return ""
+	}
+	return method
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,137 @@
+package freshdesk_test
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{"", 0, 0, false},
+		{"0", 0, 0, true},
+		{"30", 30 * time.Second, 30 * time.Second, true},
+		{"-5", 0, 0, false},
+		{"soon", 0, 0, false},
+		{time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat), 85 * time.Second, 90 * time.Second, true},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0, true},
+	}
+	for _, test := range tests {
+		delay, ok := freshdesk.ParseRetryAfter(test.value)
+		if ok != test.ok || delay < test.min || delay > test.max {
+			t.Errorf("ParseRetryAfter(%q) = %v, %v, want %v-%v, %v", test.value, delay, ok, test.min, test.max, test.ok)
+		}
+	}
+}
+
+func response(status int, retryAfter string) *http.Response {
+	res := &http.Response{StatusCode: status, Header: http.Header{}}
+	if retryAfter != "" {
+		res.Header.Set("Retry-After", retryAfter)
+	}
+	return res
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := &freshdesk.RetryPolicy{
+		MaxAttempts: 3,
+		Methods:     []string{http.MethodGet},
+		BaseDelay:   100 * time.Millisecond,
+		MaxWait:     time.Minute,
+	}
+	networkError := errors.New("connection reset")
+	tests := []struct {
+		name     string
+		method   string
+		attempt  int
+		res      *http.Response
+		err      error
+		min, max time.Duration
+		retry    bool
+	}{
+		{"success", http.MethodGet, 1, response(200, ""), nil, 0, 0, false},
+		{"client error", http.MethodGet, 1, response(404, ""), nil, 0, 0, false},
+		{"429 waits Retry-After", http.MethodPost, 1, response(429, "7"), nil, 7 * time.Second, 7 * time.Second, true},
+		{"429 without Retry-After backs off", http.MethodPost, 1, response(429, ""), nil, 50 * time.Millisecond, 100 * time.Millisecond, true},
+		{"429 longer than MaxWait", http.MethodGet, 1, response(429, "120"), nil, 0, 0, false},
+		{"5xx on a retried method", http.MethodGet, 1, response(503, ""), nil, 50 * time.Millisecond, 100 * time.Millisecond, true},
+		{"5xx backoff doubles", http.MethodGet, 2, response(502, ""), nil, 100 * time.Millisecond, 200 * time.Millisecond, true},
+		{"5xx on POST", http.MethodPost, 1, response(500, ""), nil, 0, 0, false},
+		{"network error", http.MethodGet, 1, nil, networkError, 50 * time.Millisecond, 100 * time.Millisecond, true},
+		{"network error on POST", http.MethodPost, 1, nil, networkError, 0, 0, false},
+		{"attempts exhausted", http.MethodGet, 3, response(503, ""), nil, 0, 0, false},
+	}
+	for _, test := range tests {
+		delay, retry := policy.RetryDelay(test.method, test.attempt, test.res, test.err)
+		if retry != test.retry || delay < test.min || delay > test.max {
+			t.Errorf("%s: got %v, %v, want %v-%v, %v", test.name, delay, retry, test.min, test.max, test.retry)
+		}
+	}
+
+	capped := &freshdesk.RetryPolicy{MaxAttempts: 10, Methods: []string{http.MethodGet}, BaseDelay: time.Second, MaxWait: 3 * time.Second}
+	if delay, _ := capped.RetryDelay(http.MethodGet, 8, response(500, ""), nil); delay > 3*time.Second {
+		t.Errorf("backoff %v exceeds MaxWait", delay)
+	}
+}
+
+func TestRetryAgainstServer(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Retried", Status: 2})
+	client := server.Client(&freshdesk.ClientOptions{RetryPolicy: fastRetries()})
+
+	attempts := func(method, path string) int {
+		count := 0
+		for _, req := range server.Requests() {
+			if req.Method == method && req.Path == path {
+				count++
+			}
+		}
+		return count
+	}
+	viewPath := "/api/v2/tickets/" + strconv.FormatInt(ticket.ID, 10)
+
+	// GETs are retried on 5xx until they succeed.
+	server.InjectFault(freshdesktest.Fault{Path: viewPath, Status: http.StatusServiceUnavailable, Count: 2})
+	if _, err := client.Tickets.View(ticket.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := attempts(http.MethodGet, viewPath); got != 3 {
+		t.Errorf("view took %d attempts, want 3", got)
+	}
+
+	// POSTs are not, as they may have been processed.
+	server.InjectFault(freshdesktest.Fault{Method: http.MethodPost, Status: http.StatusInternalServerError, Count: 1})
+	if _, err := client.Tickets.Create(freshdesk.CreateTicket{Email: "a@example.com", Subject: "x", Status: 2, Priority: 1}); err == nil {
+		t.Error("create succeeded despite the fault")
+	}
+	if got := attempts(http.MethodPost, "/api/v2/tickets"); got != 1 {
+		t.Errorf("create took %d attempts, want 1", got)
+	}
+
+	// A 429 is retried for any method once Retry-After has passed.
+	server.InjectFault(freshdesktest.Fault{Method: http.MethodPost, Status: http.StatusTooManyRequests, RetryAfter: 1, Count: 1})
+	start := time.Now()
+	if _, err := client.Tickets.Create(freshdesk.CreateTicket{Email: "a@example.com", Subject: "x", Status: 2, Priority: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want the 1s Retry-After", elapsed)
+	}
+
+	// Once attempts run out, the last error is returned.
+	server.InjectFault(freshdesktest.Fault{Path: viewPath, Status: http.StatusBadGateway})
+	if _, err := client.Tickets.View(ticket.ID); err == nil {
+		t.Error("view succeeded despite the fault")
+	}
+}
@@ -0,0 +1,207 @@
+package freshdesk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
+)
+
+const (
+	maxSearchPages   = 10
+	searchPageSize   = 30
+	maxSearchResults = maxSearchPages * searchPageSize
+	searchDateFormat = "2006-01-02"
+)
+
+// searchEpoch is where date-window partitioning starts; no Freshdesk account
+// holds records created before it.
+var searchEpoch = time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// searchPage fetches one page of a /search endpoint and the total number of
+// matches.
+func searchPage[T any](ctx context.Context, client *ApiClient, path string, page int) ([]T, int, error) {
+	output := struct {
+		Results []T `json:"results"`
+		Total   int `json:"total"`
+	}{}
+	_, err := client.get(ctx, withQuery(path, "page", strconv.Itoa(page)), &output)
+	if err != nil {
+		return nil, 0, err
+	}
+	return output.Results, output.Total, nil
+}
+
+// searchResults is the paging state of a /search endpoint. Freshdesk serves
+// at most 10 pages of 30 results per query, so paging stops after page 10
+// even when Total is higher, and Truncated is set.
+type searchResults[T any] struct {
+	// records names what is searched, e.g. "contacts", in the operation and
+	// in the error returned once there are no pages left.
+	records string
+	path    string
+	page    int
+	seen    int
+	// Total is the number of records matching the search.
+	Total int
+	// Truncated is set when the search matched more records than Freshdesk
+	// returns for a single query.
+	Truncated bool
+	client    *ApiClient
+}
+
+func newSearchResults[T any](client *ApiClient, records, path string) searchResults[T] {
+	return searchResults[T]{
+		records: records,
+		path:    path,
+		client:  client,
+	}
+}
+
+// more reports whether next has a page left to fetch.
+func (results searchResults[T]) more() bool {
+	if results.path == "" || results.page >= maxSearchPages {
+		return false
+	}
+	return results.page == 0 || results.seen < results.Total
+}
+
+// next fetches the page after results and returns it with the state for the
+// one after.
+func (results searchResults[T]) next(ctx context.Context) ([]T, searchResults[T], error) {
+	if !results.more() {
+		return nil, searchResults[T]{}, errors.New("no more " + results.records)
+	}
+	ctx = withOperation(ctx, results.records+".search")
+	output, total, err := searchPage[T](ctx, results.client, results.path, results.page+1)
+	if err != nil {
+		return nil, searchResults[T]{}, err
+	}
+	next := results
+	next.page++
+	next.seen += len(output)
+	if len(output) == 0 {
+		next.seen = total
+	}
+	next.Total = total
+	next.Truncated = total > maxSearchResults
+	return output, next, nil
+}
+
+// newSearchIterator streams the pages of searchResults. total, when set,
+// receives the number of matches reported by Freshdesk.
+func newSearchIterator[T any](client *ApiClient, records, path string, total *int) *Iterator[T] {
+	results := newSearchResults[T](client, records, path)
+	return newIterator(records+".search", func(ctx context.Context) ([]T, bool, error) {
+		output, next, err := results.next(ctx)
+		if err != nil {
+			return nil, true, err
+		}
+		results = next
+		if total != nil {
+			*total = results.Total
+		}
+		return output, results.more(), nil
+	})
+}
+
+// partitionedSearch collects every match of a search by splitting it into
+// created_at windows small enough to fit under the page cap. A single day
+// that is still too large is split again by updated_at.
+type partitionedSearch[T any] struct {
+	client    *ApiClient
+	endpoint  func(string) string
+	id        func(T) int64
+	seen      map[int64]bool
+	results   []T
+	truncated bool
+}
+
+func searchPartitioned[T any](ctx context.Context, client *ApiClient, endpoint func(string) string, query querybuilder.Query, id func(T) int64) ([]T, bool, error) {
+	search := &partitionedSearch[T]{
+		client:   client,
+		endpoint: endpoint,
+		id:       id,
+		seen:     map[int64]bool{},
+		results:  []T{},
+	}
+	// Records created today in a timezone ahead of UTC are dated tomorrow.
+	until := time.Now().UTC().AddDate(0, 0, 1)
+	if _, err := search.window(ctx, []querybuilder.Query{query}, "created_at", searchEpoch, until, -1); err != nil {
+		return nil, false, err
+	}
+	return search.results, search.truncated, nil
+}
+
+// window searches the terms restricted to field values between from and to,
+// both inclusive days, and returns the number of matches. total is that
+// number when the enclosing window already tells it, or -1. A window known
+// to be too large is split without fetching it; otherwise its first page
+// both gives the total and is kept, even when the window turns out to need
+// splitting.
+func (search *partitionedSearch[T]) window(ctx context.Context, terms []querybuilder.Query, field string, from, to time.Time, total int) (int, error) {
+	windowed := append(append([]querybuilder.Query{}, terms...),
+		querybuilder.Parameter(field).GreaterThanString(from.Format(searchDateFormat)),
+		querybuilder.Parameter(field).LessThanString(to.Format(searchDateFormat)),
+	)
+	path := search.endpoint(querybuilder.AllOf(windowed...).URLSafe())
+	page, fetched := 0, 0
+	if total <= maxSearchResults {
+		results, matches, err := searchPage[T](ctx, search.client, path, 1)
+		if err != nil {
+			return 0, err
+		}
+		search.add(results)
+		page, fetched, total = 1, len(results), matches
+	}
+
+	if total > maxSearchResults {
+		days := int(to.Sub(from).Hours() / 24)
+		if days > 0 {
+			mid := from.AddDate(0, 0, days/2)
+			first, err := search.window(ctx, terms, field, from, mid, -1)
+			if err != nil {
+				return 0, err
+			}
+			// The halves do not overlap, so the second one holds the
+			// matches the first one does not.
+			_, err = search.window(ctx, terms, field, mid.AddDate(0, 0, 1), to, total-first)
+			return total, err
+		}
+		if field == "created_at" {
+			// Records are never updated before they are created, so the
+			// updated_at windows cover the same matches.
+			_, err := search.window(ctx, windowed, "updated_at", from, time.Now().UTC().AddDate(0, 0, 1), total)
+			return total, err
+		}
+		search.truncated = true
+	}
+
+	for page++; fetched < total && page <= maxSearchPages; page++ {
+		results, _, err := searchPage[T](ctx, search.client, path, page)
+		if err != nil {
+			return 0, err
+		}
+		if len(results) == 0 {
+			break
+		}
+		search.add(results)
+		fetched += len(results)
+	}
+	return total, nil
+}
+
+// add appends results, skipping records that moved between windows while
+// the search ran.
+func (search *partitionedSearch[T]) add(results []T) {
+	for _, result := range results {
+		id := search.id(result)
+		if search.seen[id] {
+			continue
+		}
+		search.seen[id] = true
+		search.results = append(search.results, result)
+	}
+}
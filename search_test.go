@@ -0,0 +1,156 @@
+package freshdesk_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
+)
+
+func addTicketAt(server *freshdesktest.Server, created, updated time.Time) {
+	server.AddTicket(freshdesk.Ticket{Subject: "Search me", Status: 2, CreatedAt: &created, UpdatedAt: &updated})
+}
+
+// searchRequests returns the queries and pages of the ticket searches the
+// server received, failing the test when one was sent twice.
+func searchRequests(t *testing.T, server *freshdesktest.Server) []string {
+	t.Helper()
+	seen := map[string]bool{}
+	requests := []string{}
+	for _, req := range server.Requests() {
+		if req.Method != http.MethodGet || req.Path != "/api/v2/search/tickets" {
+			continue
+		}
+		key := req.Query.Get("query") + " page " + req.Query.Get("page")
+		if seen[key] {
+			t.Errorf("searched %s twice", key)
+		}
+		seen[key] = true
+		requests = append(requests, key)
+	}
+	return requests
+}
+
+func uniqueTickets(t *testing.T, tickets freshdesk.TicketSlice) int {
+	t.Helper()
+	ids := map[int64]bool{}
+	for _, ticket := range tickets {
+		if ids[ticket.ID] {
+			t.Errorf("ticket %d returned twice", ticket.ID)
+		}
+		ids[ticket.ID] = true
+	}
+	return len(ids)
+}
+
+func TestSearchAllSplitsByCreatedAt(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 700; i++ {
+		created := start.Add(time.Duration(i) * 9 * time.Hour)
+		addTicketAt(server, created, created)
+	}
+	client := server.Client(nil)
+
+	results, err := client.Tickets.SearchAll(querybuilder.Parameter("status").Equals(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uniqueTickets(t, results.Results) != 700 || results.Truncated {
+		t.Errorf("got %d tickets, Truncated = %v, want 700 and false", len(results.Results), results.Truncated)
+	}
+	requests := searchRequests(t, server)
+	for _, request := range requests {
+		if strings.Contains(request, "updated_at") {
+			t.Errorf("searched by updated_at although created_at days could be split: %s", request)
+		}
+	}
+	// Second halves known to be too large from their parent's total are
+	// split without being fetched, and no fetched page is thrown away.
+	if len(requests) > 32 {
+		t.Errorf("sent %d searches, want at most 32", len(requests))
+	}
+}
+
+func TestSearchAllFallsBackToUpdatedAt(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	// Every ticket is created on the same day, so only updated_at can split
+	// them.
+	created := time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 400; i++ {
+		addTicketAt(server, created.Add(time.Duration(i)*time.Minute), created.AddDate(0, 0, i%40))
+	}
+	client := server.Client(nil)
+
+	results, err := client.Tickets.SearchAll(querybuilder.Parameter("status").Equals(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uniqueTickets(t, results.Results) != 400 || results.Truncated {
+		t.Errorf("got %d tickets, Truncated = %v, want 400 and false", len(results.Results), results.Truncated)
+	}
+	byUpdatedAt := 0
+	for _, request := range searchRequests(t, server) {
+		if strings.Contains(request, "updated_at") {
+			if !strings.Contains(request, "created_at:>'2023-05-04' AND created_at:<'2023-05-04'") {
+				t.Errorf("updated_at window not restricted to the creation day: %s", request)
+			}
+			byUpdatedAt++
+		}
+	}
+	if byUpdatedAt == 0 {
+		t.Error("no search split by updated_at")
+	}
+}
+
+func TestSearchAllTruncatesASingleInstant(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	instant := time.Date(2023, 8, 9, 10, 11, 12, 0, time.UTC)
+	for i := 0; i < 350; i++ {
+		addTicketAt(server, instant, instant)
+	}
+	client := server.Client(nil)
+
+	results, err := client.Tickets.SearchAll(querybuilder.Parameter("status").Equals(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results.Truncated {
+		t.Error("Truncated not set for a window that cannot be split")
+	}
+	if uniqueTickets(t, results.Results) != 300 {
+		t.Errorf("got %d tickets, want the 300 Freshdesk returns", len(results.Results))
+	}
+	searchRequests(t, server)
+}
+
+func TestSearchAllDayBoundaries(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	// Windows are whole days on both ends; tickets on the first and last
+	// second of a day must be found exactly once.
+	day := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 200; i++ {
+		first := day.AddDate(0, 0, i)
+		last := first.Add(24*time.Hour - time.Second)
+		addTicketAt(server, first, first)
+		addTicketAt(server, last, last)
+	}
+	client := server.Client(nil)
+
+	results, err := client.Tickets.SearchAll(querybuilder.Parameter("status").Equals(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uniqueTickets(t, results.Results) != 400 || results.Truncated {
+		t.Errorf("got %d tickets, Truncated = %v, want 400 and false", len(results.Results), results.Truncated)
+	}
+	searchRequests(t, server)
+}
@@ -1,6 +1,7 @@
 package freshdesk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,7 +9,10 @@ import (
 
 type SLAPolicyManager interface {
 	All() (SLAPolicySlice, error)
+	AllContext(context.Context) (SLAPolicySlice, error)
+	List(*ListOptions) *Iterator[SLAPolicy]
 	Update(int64, SLAPolicy) (SLAPolicy, error)
+	UpdateContext(context.Context, int64, SLAPolicy) (SLAPolicy, error)
 }
 
 type slaPolicyManager struct {
@@ -61,26 +65,35 @@ func (slice SLAPolicySlice) Print() {
 }
 
 func (manager slaPolicyManager) All() (SLAPolicySlice, error) {
-	output := SLAPolicySlice{}
-	_, err := manager.client.get(endpoints.slaPolicies.all, &output)
+	return manager.AllContext(context.Background())
+}
+
+func (manager slaPolicyManager) AllContext(ctx context.Context) (SLAPolicySlice, error) {
+	output, err := manager.List(nil).All(ctx)
 	if err != nil {
 		return SLAPolicySlice{}, err
 	}
-	outputWithClient := SLAPolicySlice{}
-	for _, policy := range output {
+	return output, nil
+}
+
+func (manager slaPolicyManager) List(options *ListOptions) *Iterator[SLAPolicy] {
+	return newLinkIterator(manager.client, endpoints.slaPolicies.all, options, "sla_policies.list", func(policy *SLAPolicy) {
 		policy.client = manager.client
-		outputWithClient = append(outputWithClient, policy)
-	}
-	return outputWithClient, nil
+	})
 }
 
 func (manager slaPolicyManager) Update(id int64, policy SLAPolicy) (SLAPolicy, error) {
+	return manager.UpdateContext(context.Background(), id, policy)
+}
+
+func (manager slaPolicyManager) UpdateContext(ctx context.Context, id int64, policy SLAPolicy) (SLAPolicy, error) {
+	ctx = withOperation(ctx, "sla_policies.update")
 	output := SLAPolicy{}
 	jsonb, err := json.Marshal(policy)
 	if err != nil {
 		return output, nil
 	}
-	err = manager.client.put(endpoints.slaPolicies.update(id), jsonb, &output, http.StatusOK)
+	err = manager.client.put(ctx, endpoints.slaPolicies.update(id), jsonb, &output, http.StatusOK)
 	if err != nil {
 		return SLAPolicy{}, err
 	}
@@ -89,6 +102,11 @@ func (manager slaPolicyManager) Update(id int64, policy SLAPolicy) (SLAPolicy, e
 
 // EnsureCompanyPresent indempotently ensures an SLA policy is applied to a Company
 func (policy SLAPolicy) EnsureCompanyPresent(companyID int) {
+	policy.EnsureCompanyPresentContext(context.Background(), companyID)
+}
+
+func (policy SLAPolicy) EnsureCompanyPresentContext(ctx context.Context, companyID int) {
+	ctx = withOperation(ctx, "sla_policies.update")
 	newCompanyIDs := []int{}
 	for key, values := range policy.ApplicableTo {
 		if key == "company_ids" {
@@ -105,25 +123,22 @@ func (policy SLAPolicy) EnsureCompanyPresent(companyID int) {
 	changes := &SLAPolicyApplicableCompanyList{}
 	changes.ApplicableTo.CompanyIDs = append(newCompanyIDs, companyID)
 	jsonb, err := json.Marshal(changes)
-	if err != nil && policy.client.logger != nil {
-		policy.client.logger.Println(err.Error())
-	}
+	policy.client.logErr(err)
 	output := SLAPolicy{}
-	policy.client.put(endpoints.slaPolicies.update(policy.ID), jsonb, &output, http.StatusOK)
-	jsonb, err = json.MarshalIndent(output, "", "\t")
-	if err != nil {
-		if policy.client.logger != nil {
-			policy.client.logger.Println(err.Error())
-		}
-	} else {
-		if policy.client.logger != nil {
-			policy.client.logger.Println(string(jsonb))
-		}
+	err = policy.client.put(ctx, endpoints.slaPolicies.update(policy.ID), jsonb, &output, http.StatusOK)
+	policy.client.logErr(err)
+	if err == nil && policy.client.log != nil {
+		policy.client.log.Info("freshdesk: SLA policy updated", "id", output.ID, "name", output.Name, "applicable_to", output.ApplicableTo)
 	}
 }
 
 // EnsureCompanyAbsent indempotently ensures an SLA policy is applied to a Company
 func (policy SLAPolicy) EnsureCompanyAbsent(companyID int) {
+	policy.EnsureCompanyAbsentContext(context.Background(), companyID)
+}
+
+func (policy SLAPolicy) EnsureCompanyAbsentContext(ctx context.Context, companyID int) {
+	ctx = withOperation(ctx, "sla_policies.update")
 	// Check for the company_ids key and skip if it is not present
 	mapContainsCompaniesFlag := false
 	for key := range policy.ApplicableTo {
@@ -151,19 +166,11 @@ func (policy SLAPolicy) EnsureCompanyAbsent(companyID int) {
 	changes := &SLAPolicyApplicableCompanyList{}
 	changes.ApplicableTo.CompanyIDs = newCompanyIDs
 	jsonb, err := json.Marshal(changes)
-	if err != nil && policy.client.logger != nil {
-		policy.client.logger.Println(err.Error())
-	}
+	policy.client.logErr(err)
 	output := SLAPolicy{}
-	policy.client.put(endpoints.slaPolicies.update(policy.ID), jsonb, &output, http.StatusOK)
-	jsonb, err = json.MarshalIndent(output, "", "\t")
-	if err != nil {
-		if policy.client.logger != nil {
-			policy.client.logger.Println(err.Error())
-		}
-	} else {
-		if policy.client.logger != nil {
-			policy.client.logger.Println(string(jsonb))
-		}
+	err = policy.client.put(ctx, endpoints.slaPolicies.update(policy.ID), jsonb, &output, http.StatusOK)
+	policy.client.logErr(err)
+	if err == nil && policy.client.log != nil {
+		policy.client.log.Info("freshdesk: SLA policy updated", "id", output.ID, "name", output.Name, "applicable_to", output.ApplicableTo)
 	}
 }
@@ -1,12 +1,16 @@
 package freshdesk
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"time"
 )
 
 type SolutionManager interface {
 	Categories() (CategorySlice, error)
+	CategoriesContext(context.Context) (CategorySlice, error)
+	ListCategories(*ListOptions) *Iterator[Category]
 }
 
 type solutionManager struct {
@@ -86,7 +90,7 @@ type Article struct {
 	Description     string            `json:"description,omitempty"`
 	DescriptionText string            `json:"description_text"`
 	AgentID         int64             `json:"agent_id"`
-	CategoryID      int64             `json:"updated_at"`
+	CategoryID      int64             `json:"category_id"`
 	FolderID        int64             `json:"folder_id"`
 	Hits            int               `json:"hits"`
 	Status          int               `json:"status"`
@@ -94,7 +98,7 @@ type Article struct {
 	Tags            []string          `json:"tags"`
 	ThumbsDown      int               `json:"thumbs_down"`
 	ThumbsUp        int               `json:"thumbs_up"`
-	Type            int               `json:"updated_at"`
+	Type            int               `json:"type"`
 	CreatedAt       *time.Time        `json:"created_at"`
 	UpdatedAt       *time.Time        `json:"updated_at"`
 	client          *ApiClient
@@ -121,47 +125,64 @@ func (slice ArticleSlice) Print() {
 }
 
 func (manager solutionManager) Categories() (CategorySlice, error) {
-	output := CategorySlice{}
-	_, err := manager.client.get(endpoints.solutions.categories, &output)
+	return manager.CategoriesContext(context.Background())
+}
+
+func (manager solutionManager) CategoriesContext(ctx context.Context) (CategorySlice, error) {
+	output, err := manager.ListCategories(nil).All(ctx)
 	if err != nil {
 		return CategorySlice{}, err
 	}
-	outputWithClient := CategorySlice{}
-	for _, category := range output {
+	return output, nil
+}
+
+func (manager solutionManager) ListCategories(options *ListOptions) *Iterator[Category] {
+	return newLinkIterator(manager.client, endpoints.solutions.categories, options, "solutions.categories.list", func(category *Category) {
 		category.client = manager.client
-		outputWithClient = append(outputWithClient, category)
-	}
-	return outputWithClient, nil
+	})
 }
 
 func (category Category) Folders() (FolderSlice, error) {
-	output := FolderSlice{}
-	_, err := category.client.get(endpoints.solutions.category.folders(category.ID), &output)
+	return category.FoldersContext(context.Background())
+}
+
+func (category Category) FoldersContext(ctx context.Context) (FolderSlice, error) {
+	output, err := category.ListFolders(nil).All(ctx)
 	if err != nil {
 		return FolderSlice{}, err
 	}
-	outputWithClient := FolderSlice{}
-	for _, folder := range output {
+	return output, nil
+}
+
+func (category Category) ListFolders(options *ListOptions) *Iterator[Folder] {
+	return newLinkIterator(category.client, endpoints.solutions.category.folders(category.ID), options, "solutions.folders.list", func(folder *Folder) {
 		folder.client = category.client
-		outputWithClient = append(outputWithClient, folder)
-	}
-	return outputWithClient, nil
+	})
 }
 
 func (folder Folder) Articles() (ArticleSlice, error) {
-	output := ArticleSlice{}
-	_, err := folder.client.get(endpoints.solutions.folder.articles(folder.ID), &output)
+	return folder.ArticlesContext(context.Background())
+}
+
+func (folder Folder) ArticlesContext(ctx context.Context) (ArticleSlice, error) {
+	output, err := folder.ListArticles(nil).All(ctx)
 	if err != nil {
 		return ArticleSlice{}, err
 	}
-	outputWithClient := ArticleSlice{}
-	for _, article := range output {
+	return output, nil
+}
+
+func (folder Folder) ListArticles(options *ListOptions) *Iterator[Article] {
+	return newLinkIterator(folder.client, endpoints.solutions.folder.articles(folder.ID), options, "solutions.articles.list", func(article *Article) {
 		article.client = folder.client
-		outputWithClient = append(outputWithClient, article)
-	}
-	return outputWithClient, nil
+	})
 }
 
 func (article Article) Delete() error {
-	return article.client.delete(endpoints.solutions.articles.delete(article.ID))
+	return article.DeleteContext(context.Background())
+}
+
+func (article Article) DeleteContext(ctx context.Context) error {
+	ctx = withOperation(ctx, "solutions.articles.delete")
+	return article.client.delete(ctx, endpoints.solutions.articles.delete(article.ID), http.StatusNoContent)
 }
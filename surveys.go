@@ -0,0 +1,261 @@
+package freshdesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type SurveyManager interface {
+	All() (SurveySlice, error)
+	AllContext(context.Context) (SurveySlice, error)
+	List(*ListOptions) *Iterator[Survey]
+}
+
+type SatisfactionRatingManager interface {
+	All(*ListSatisfactionRatingsOptions) (SatisfactionRatingSlice, error)
+	AllContext(context.Context, *ListSatisfactionRatingsOptions) (SatisfactionRatingSlice, error)
+	List(*ListSatisfactionRatingsOptions) *Iterator[SatisfactionRating]
+	ForTicket(int64) (SatisfactionRatingSlice, error)
+	ForTicketContext(context.Context, int64) (SatisfactionRatingSlice, error)
+	ListForTicket(int64, *ListOptions) *Iterator[SatisfactionRating]
+	Create(int64, CreateSatisfactionRating) (SatisfactionRating, error)
+	CreateContext(context.Context, int64, CreateSatisfactionRating) (SatisfactionRating, error)
+}
+
+type surveyManager struct {
+	client *ApiClient
+}
+
+type satisfactionRatingManager struct {
+	client *ApiClient
+}
+
+func newSurveyManager(client *ApiClient) surveyManager {
+	return surveyManager{
+		client,
+	}
+}
+
+func newSatisfactionRatingManager(client *ApiClient) satisfactionRatingManager {
+	return satisfactionRatingManager{
+		client,
+	}
+}
+
+// Rating is an answer to a survey question. Three-point surveys only use
+// RatingExtremelyHappy, RatingNeutral and RatingExtremelyUnhappy.
+type Rating int
+
+const (
+	RatingExtremelyUnhappy Rating = -103
+	RatingVeryUnhappy      Rating = -102
+	RatingUnhappy          Rating = -101
+	RatingNeutral          Rating = 100
+	RatingHappy            Rating = 101
+	RatingVeryHappy        Rating = 102
+	RatingExtremelyHappy   Rating = 103
+)
+
+// DefaultQuestion is the key of the main survey question in
+// SatisfactionRating.Ratings.
+const DefaultQuestion = "default_question"
+
+func (r Rating) Value() int {
+	return int(r)
+}
+
+func (r Rating) String() string {
+	switch r {
+	case RatingExtremelyUnhappy:
+		return "Extremely Unhappy"
+	case RatingVeryUnhappy:
+		return "Very Unhappy"
+	case RatingUnhappy:
+		return "Unhappy"
+	case RatingNeutral:
+		return "Neutral"
+	case RatingHappy:
+		return "Happy"
+	case RatingVeryHappy:
+		return "Very Happy"
+	case RatingExtremelyHappy:
+		return "Extremely Happy"
+	}
+	return strconv.Itoa(int(r))
+}
+
+// Positive reports whether the rating is above neutral.
+func (r Rating) Positive() bool {
+	return r > RatingNeutral
+}
+
+type Survey struct {
+	ID        int64            `json:"id"`
+	Title     string           `json:"title"`
+	Active    bool             `json:"active"`
+	Questions []SurveyQuestion `json:"questions"`
+	CreatedAt *time.Time       `json:"created_at"`
+	UpdatedAt *time.Time       `json:"updated_at"`
+}
+
+type SurveyQuestion struct {
+	ID              string   `json:"id"`
+	Label           string   `json:"label"`
+	AcceptedRatings []Rating `json:"accepted_ratings"`
+	Default         bool     `json:"default"`
+}
+
+type SatisfactionRating struct {
+	ID       int64  `json:"id"`
+	SurveyID int64  `json:"survey_id"`
+	UserID   int64  `json:"user_id"`
+	AgentID  int64  `json:"agent_id"`
+	GroupID  int64  `json:"group_id"`
+	TicketID int64  `json:"ticket_id"`
+	Feedback string `json:"feedback"`
+	// Ratings maps question IDs, DefaultQuestion for the main one, to
+	// answers.
+	Ratings   map[string]Rating `json:"ratings"`
+	CreatedAt *time.Time        `json:"created_at"`
+	UpdatedAt *time.Time        `json:"updated_at"`
+}
+
+type CreateSatisfactionRating struct {
+	Ratings  map[string]Rating `json:"ratings"`
+	Feedback string            `json:"feedback,omitempty"`
+}
+
+// ListSatisfactionRatingsOptions filters the satisfaction ratings of the
+// account. Zero fields are left out of the request.
+type ListSatisfactionRatingsOptions struct {
+	ListOptions
+	CreatedSince time.Time
+	UserID       int64
+}
+
+func (options *ListSatisfactionRatingsOptions) apply(path string) string {
+	if options == nil {
+		return path
+	}
+	query := url.Values{}
+	if !options.CreatedSince.IsZero() {
+		query.Set("created_since", options.CreatedSince.UTC().Format(time.RFC3339))
+	}
+	if options.UserID != 0 {
+		query.Set("user_id", strconv.FormatInt(options.UserID, 10))
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
+}
+
+// Rating returns the answer to the main survey question.
+func (rating SatisfactionRating) Rating() Rating {
+	return rating.Ratings[DefaultQuestion]
+}
+
+type SurveySlice []Survey
+
+func (s SurveySlice) Len() int { return len(s) }
+
+func (s SurveySlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s SurveySlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s SurveySlice) Print() {
+	for _, survey := range s {
+		fmt.Println(survey.Title)
+	}
+}
+
+type SatisfactionRatingSlice []SatisfactionRating
+
+func (s SatisfactionRatingSlice) Len() int { return len(s) }
+
+func (s SatisfactionRatingSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s SatisfactionRatingSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s SatisfactionRatingSlice) Print() {
+	for _, rating := range s {
+		fmt.Printf("%d: %s\n", rating.TicketID, rating.Rating())
+	}
+}
+
+func (manager surveyManager) All() (SurveySlice, error) {
+	return manager.AllContext(context.Background())
+}
+
+func (manager surveyManager) AllContext(ctx context.Context) (SurveySlice, error) {
+	output, err := manager.List(nil).All(ctx)
+	if err != nil {
+		return SurveySlice{}, err
+	}
+	return output, nil
+}
+
+func (manager surveyManager) List(options *ListOptions) *Iterator[Survey] {
+	return newLinkIterator[Survey](manager.client, endpoints.surveys.all, options, "surveys.list", nil)
+}
+
+func (manager satisfactionRatingManager) All(options *ListSatisfactionRatingsOptions) (SatisfactionRatingSlice, error) {
+	return manager.AllContext(context.Background(), options)
+}
+
+func (manager satisfactionRatingManager) AllContext(ctx context.Context, options *ListSatisfactionRatingsOptions) (SatisfactionRatingSlice, error) {
+	output, err := manager.List(options).All(ctx)
+	if err != nil {
+		return SatisfactionRatingSlice{}, err
+	}
+	return output, nil
+}
+
+func (manager satisfactionRatingManager) List(options *ListSatisfactionRatingsOptions) *Iterator[SatisfactionRating] {
+	path := endpoints.surveys.satisfactionRatings
+	var listOptions *ListOptions
+	if options != nil {
+		path = options.apply(path)
+		listOptions = &options.ListOptions
+	}
+	return newLinkIterator[SatisfactionRating](manager.client, path, listOptions, "satisfaction_ratings.list", nil)
+}
+
+func (manager satisfactionRatingManager) ForTicket(ticketID int64) (SatisfactionRatingSlice, error) {
+	return manager.ForTicketContext(context.Background(), ticketID)
+}
+
+func (manager satisfactionRatingManager) ForTicketContext(ctx context.Context, ticketID int64) (SatisfactionRatingSlice, error) {
+	output, err := manager.ListForTicket(ticketID, nil).All(ctx)
+	if err != nil {
+		return SatisfactionRatingSlice{}, err
+	}
+	return output, nil
+}
+
+func (manager satisfactionRatingManager) ListForTicket(ticketID int64, options *ListOptions) *Iterator[SatisfactionRating] {
+	return newLinkIterator[SatisfactionRating](manager.client, endpoints.tickets.satisfactionRatings(ticketID), options, "tickets.satisfaction_ratings.list", nil)
+}
+
+func (manager satisfactionRatingManager) Create(ticketID int64, rating CreateSatisfactionRating) (SatisfactionRating, error) {
+	return manager.CreateContext(context.Background(), ticketID, rating)
+}
+
+func (manager satisfactionRatingManager) CreateContext(ctx context.Context, ticketID int64, rating CreateSatisfactionRating) (SatisfactionRating, error) {
+	ctx = withOperation(ctx, "satisfaction_ratings.create")
+	output := SatisfactionRating{}
+	jsonb, err := json.Marshal(rating)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.postJSON(ctx, endpoints.tickets.satisfactionRatings(ticketID), jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return SatisfactionRating{}, err
+	}
+	return output, nil
+}
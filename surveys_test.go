@@ -0,0 +1,103 @@
+package freshdesk_test
+
+import (
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestSurveys(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddSurvey(freshdesk.Survey{Title: "Retired", Active: false})
+	survey := server.AddSurvey(freshdesk.Survey{Title: "CSAT", Active: true, Questions: []freshdesk.SurveyQuestion{{
+		ID:              "question_1",
+		Label:           "How did we do?",
+		Default:         true,
+		AcceptedRatings: []freshdesk.Rating{freshdesk.RatingExtremelyUnhappy, freshdesk.RatingNeutral, freshdesk.RatingExtremelyHappy},
+	}}})
+	client := server.Client(nil)
+
+	surveys, err := client.Surveys.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(surveys) != 2 || surveys[1].ID != survey.ID || !surveys[1].Active {
+		t.Fatalf("surveys = %+v", surveys)
+	}
+	question := surveys[1].Questions[0]
+	if !question.Default || len(question.AcceptedRatings) != 3 || question.AcceptedRatings[2] != freshdesk.RatingExtremelyHappy {
+		t.Errorf("question = %+v", question)
+	}
+}
+
+func TestSatisfactionRatings(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	server.Now = func() time.Time { return now }
+	survey := server.AddSurvey(freshdesk.Survey{Title: "CSAT", Active: true, Questions: []freshdesk.SurveyQuestion{{
+		ID:              "question_1",
+		Default:         true,
+		AcceptedRatings: []freshdesk.Rating{freshdesk.RatingExtremelyUnhappy, freshdesk.RatingNeutral, freshdesk.RatingExtremelyHappy},
+	}}})
+	old := server.AddTicket(freshdesk.Ticket{Subject: "Old", Status: 5, RequesterID: 1})
+	recent := server.AddTicket(freshdesk.Ticket{Subject: "Recent", Status: 5, RequesterID: 2})
+	client := server.Client(nil)
+
+	if _, err := client.SatisfactionRatings.Create(old.ID, freshdesk.CreateSatisfactionRating{
+		Ratings: map[string]freshdesk.Rating{freshdesk.DefaultQuestion: freshdesk.RatingExtremelyUnhappy},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(48 * time.Hour)
+	rating, err := client.SatisfactionRatings.Create(recent.ID, freshdesk.CreateSatisfactionRating{
+		Ratings:  map[string]freshdesk.Rating{freshdesk.DefaultQuestion: freshdesk.RatingExtremelyHappy},
+		Feedback: "Quick fix",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rating.TicketID != recent.ID || rating.SurveyID != survey.ID || rating.UserID != 2 || rating.Feedback != "Quick fix" {
+		t.Errorf("rating = %+v", rating)
+	}
+	if rating.Rating() != freshdesk.RatingExtremelyHappy || !rating.Rating().Positive() {
+		t.Errorf("rating is %s, want %s", rating.Rating(), freshdesk.RatingExtremelyHappy)
+	}
+
+	// Ratings outside the survey's scale are rejected.
+	if _, err := client.SatisfactionRatings.Create(recent.ID, freshdesk.CreateSatisfactionRating{
+		Ratings: map[string]freshdesk.Rating{freshdesk.DefaultQuestion: freshdesk.RatingVeryHappy},
+	}); !freshdesk.IsValidationError(err) {
+		t.Errorf("err = %v, want a validation error", err)
+	}
+
+	forTicket, err := client.SatisfactionRatings.ForTicket(recent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forTicket) != 1 || forTicket[0].ID != rating.ID {
+		t.Errorf("ratings of the ticket = %+v", forTicket)
+	}
+	tests := []struct {
+		name    string
+		options *freshdesk.ListSatisfactionRatingsOptions
+		want    int
+	}{
+		{"all", nil, 2},
+		{"created since", &freshdesk.ListSatisfactionRatingsOptions{CreatedSince: now.Add(-time.Hour)}, 1},
+		{"user", &freshdesk.ListSatisfactionRatingsOptions{UserID: 1}, 1},
+		{"paged", &freshdesk.ListSatisfactionRatingsOptions{ListOptions: freshdesk.ListOptions{PerPage: 1}}, 2},
+	}
+	for _, test := range tests {
+		ratings, err := client.SatisfactionRatings.All(test.options)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(ratings) != test.want {
+			t.Errorf("%s: got %d ratings, want %d", test.name, len(ratings), test.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package freshdesk
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/nextlinktechnology/go-freshdesk"
+
+type operationKey struct{}
+
+// withOperation names the logical operation, e.g. "tickets.view", that the
+// requests sent with ctx belong to. It is used for span names and metric
+// attributes.
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// Operation returns the logical operation name attached to ctx by the client,
+// prefixed with "freshdesk.", or an empty string. Middleware can use it to
+// label requests.
+func Operation(ctx context.Context) string {
+	if operation, ok := ctx.Value(operationKey{}).(string); ok {
+		return "freshdesk." + operation
+	}
+	return ""
+}
+
+// endpointTemplate replaces the IDs in a request path with "{id}" and drops
+// the query string, e.g. /api/v2/tickets/{id}/conversations.
+func endpointTemplate(path string) string {
+	segments := strings.Split(strings.SplitN(path, "?", 2)[0], "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// telemetry holds the OpenTelemetry instruments of a client. A nil
+// *telemetry records nothing.
+type telemetry struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+}
+
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider, rateLimit *rateLimitTracker) (*telemetry, error) {
+	if tracerProvider == nil && meterProvider == nil {
+		return nil, nil
+	}
+	t := &telemetry{}
+	if tracerProvider != nil {
+		t.tracer = tracerProvider.Tracer(instrumentationName)
+	}
+	if meterProvider == nil {
+		return t, nil
+	}
+
+	meter := meterProvider.Meter(instrumentationName)
+	var err error
+	t.duration, err = meter.Float64Histogram("freshdesk.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Freshdesk API calls, retries included."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.requests, err = meter.Int64Counter("freshdesk.client.requests",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of Freshdesk API calls."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.errors, err = meter.Int64Counter("freshdesk.client.errors",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of Freshdesk API calls that failed or returned an error status."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	_, err = meter.Int64ObservableGauge("freshdesk.client.rate_limit.remaining",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Remaining Freshdesk API calls as reported by X-Ratelimit-Remaining."),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			if state := rateLimit.get(); !state.UpdatedAt.IsZero() {
+				observer.Observe(int64(state.Remaining))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// start begins the span of an API call. The returned function ends it and
+// records the call's metrics once every attempt is done.
+func (t *telemetry) start(ctx context.Context, method, path string) (context.Context, func(*http.Response, error, int)) {
+	if t == nil {
+		return ctx, func(*http.Response, error, int) {}
+	}
+	operation := Operation(ctx)
+	template := endpointTemplate(path)
+	if operation == "" {
+		operation = "freshdesk " + method + " " + template
+	}
+	attributes := []attribute.KeyValue{
+		attribute.String("freshdesk.operation", operation),
+		attribute.String("http.request.method", method),
+		attribute.String("url.template", template),
+	}
+
+	var span trace.Span
+	if t.tracer != nil {
+		ctx, span = t.tracer.Start(ctx, operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attributes...),
+		)
+	}
+	start := time.Now()
+
+	return ctx, func(res *http.Response, err error, retries int) {
+		failed := err != nil || res.StatusCode >= http.StatusBadRequest
+		if res != nil {
+			attributes = append(attributes, attribute.Int("http.response.status_code", res.StatusCode))
+		}
+		if span != nil {
+			span.SetAttributes(attributes...)
+			span.SetAttributes(attribute.Int("freshdesk.retry_count", retries))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else if failed {
+				span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+			}
+			span.End()
+		}
+		if t.duration == nil {
+			return
+		}
+		recordOptions := metric.WithAttributes(attributes...)
+		t.duration.Record(ctx, time.Since(start).Seconds(), recordOptions)
+		t.requests.Add(ctx, 1, recordOptions)
+		if failed {
+			t.errors.Add(ctx, 1, recordOptions)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package freshdesk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// rateLimitHeaders adds the X-Ratelimit-* headers Freshdesk sends, which
+// the fake server leaves out.
+func rateLimitHeaders(remaining string) freshdesk.Middleware {
+	return func(next freshdesk.RoundTripFunc) freshdesk.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			res, err := next(req)
+			if err == nil {
+				res.Header.Set("X-Ratelimit-Total", "700")
+				res.Header.Set("X-Ratelimit-Remaining", remaining)
+				res.Header.Set("X-Ratelimit-Used-CurrentRequest", "1")
+			}
+			return res, err
+		}
+	}
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestTelemetry(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Traced", Status: 2})
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	client := server.Client(&freshdesk.ClientOptions{
+		RetryPolicy:    fastRetries(),
+		Middleware:     []freshdesk.Middleware{rateLimitHeaders("642")},
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+
+	// The first attempt fails and is retried within the same span.
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/tickets/", Status: http.StatusBadGateway, Count: 1})
+	if _, err := client.Tickets.View(ticket.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Tickets.View(ticket.ID + 1000); err == nil {
+		t.Fatal("viewing a missing ticket returned no error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("got %d spans, want 2", len(ended))
+	}
+	for i, want := range []struct {
+		status  int64
+		retries int64
+		code    codes.Code
+	}{
+		{http.StatusOK, 1, codes.Unset},
+		{http.StatusNotFound, 0, codes.Error},
+	} {
+		span := ended[i]
+		if span.Name() != "freshdesk.tickets.view" {
+			t.Errorf("span %d is named %q, want freshdesk.tickets.view", i, span.Name())
+		}
+		attributes := spanAttributes(span)
+		if got := attributes["url.template"].AsString(); got != "/api/v2/tickets/{id}" {
+			t.Errorf("span %d url.template = %q", i, got)
+		}
+		if got := attributes["http.request.method"].AsString(); got != http.MethodGet {
+			t.Errorf("span %d http.request.method = %q", i, got)
+		}
+		if got := attributes["http.response.status_code"].AsInt64(); got != want.status {
+			t.Errorf("span %d http.response.status_code = %d, want %d", i, got, want.status)
+		}
+		if got := attributes["freshdesk.retry_count"].AsInt64(); got != want.retries {
+			t.Errorf("span %d freshdesk.retry_count = %d, want %d", i, got, want.retries)
+		}
+		if span.Status().Code != want.code {
+			t.Errorf("span %d status = %v, want %v", i, span.Status().Code, want.code)
+		}
+	}
+
+	metrics := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]metricdata.Metrics{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			found[m.Name] = m
+		}
+	}
+
+	sum := func(name string) int64 {
+		data, ok := found[name].Data.(metricdata.Sum[int64])
+		if !ok {
+			t.Fatalf("metric %s missing or not an int64 sum", name)
+		}
+		total := int64(0)
+		for _, point := range data.DataPoints {
+			total += point.Value
+		}
+		return total
+	}
+	if got := sum("freshdesk.client.requests"); got != 2 {
+		t.Errorf("freshdesk.client.requests = %d, want 2", got)
+	}
+	if got := sum("freshdesk.client.errors"); got != 1 {
+		t.Errorf("freshdesk.client.errors = %d, want 1", got)
+	}
+	errors := found["freshdesk.client.errors"].Data.(metricdata.Sum[int64]).DataPoints
+	if status, _ := errors[0].Attributes.Value("http.response.status_code"); status.AsInt64() != http.StatusNotFound {
+		t.Errorf("error recorded with status %d, want 404", status.AsInt64())
+	}
+
+	gauge, ok := found["freshdesk.client.rate_limit.remaining"].Data.(metricdata.Gauge[int64])
+	if !ok || len(gauge.DataPoints) != 1 || gauge.DataPoints[0].Value != 642 {
+		t.Errorf("freshdesk.client.rate_limit.remaining = %+v, want 642", found["freshdesk.client.rate_limit.remaining"].Data)
+	}
+
+	duration, ok := found["freshdesk.client.request.duration"].Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatal("freshdesk.client.request.duration missing")
+	}
+	count := uint64(0)
+	for _, point := range duration.DataPoints {
+		count += point.Count
+	}
+	if count != 2 {
+		t.Errorf("freshdesk.client.request.duration has %d records, want 2", count)
+	}
+}
+
+func TestTelemetryDisabled(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Untraced", Status: 2})
+	client := server.Client(nil)
+	if _, err := client.Tickets.View(ticket.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	var operations []string
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	client := server.Client(&freshdesk.ClientOptions{
+		Middleware: []freshdesk.Middleware{func(next freshdesk.RoundTripFunc) freshdesk.RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				operations = append(operations, freshdesk.Operation(req.Context()))
+				return next(req)
+			}
+		}},
+	})
+	client.Tickets.All()
+	client.Groups.All()
+	if len(operations) != 2 || operations[0] != "freshdesk.tickets.list" || operations[1] != "freshdesk.groups.list" {
+		t.Errorf("operations = %v", operations)
+	}
+	if got := freshdesk.Operation(context.Background()); got != "" {
+		t.Errorf("Operation without one = %q, want empty", got)
+	}
+}
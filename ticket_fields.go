@@ -0,0 +1,424 @@
+package freshdesk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of ticket fields. Default fields have a default_ prefix, e.g.
+// default_status.
+const (
+	FieldTypeText      = "custom_text"
+	FieldTypeParagraph = "custom_paragraph"
+	FieldTypeCheckbox  = "custom_checkbox"
+	FieldTypeNumber    = "custom_number"
+	FieldTypeDecimal   = "custom_decimal"
+	FieldTypeDate      = "custom_date"
+	FieldTypeDropdown  = "custom_dropdown"
+	FieldTypeNested    = "nested_field"
+)
+
+type TicketField struct {
+	ID                   int64  `json:"id"`
+	Name                 string `json:"name"`
+	Label                string `json:"label"`
+	LabelForCustomers    string `json:"label_for_customers"`
+	Description          string `json:"description"`
+	Position             int    `json:"position"`
+	Type                 string `json:"type"`
+	Default              bool   `json:"default"`
+	RequiredForClosure   bool   `json:"required_for_closure"`
+	RequiredForAgents    bool   `json:"required_for_agents"`
+	RequiredForCustomers bool   `json:"required_for_customers"`
+	CustomersCanEdit     bool   `json:"customers_can_edit"`
+	DisplayedToCustomers bool   `json:"displayed_to_customers"`
+	// Choices are the options of dropdown, nested and default status,
+	// priority and source fields. Nested fields hold the choices of their
+	// next level in each choice.
+	Choices []TicketFieldChoice `json:"choices"`
+	// DependentFields are the second and third levels of a nested field.
+	DependentFields []DependentField     `json:"dependent_fields"`
+	HasSection      bool                 `json:"has_section"`
+	Sections        []TicketFieldSection `json:"sections"`
+	CreatedAt       *time.Time           `json:"created_at"`
+	UpdatedAt       *time.Time           `json:"updated_at"`
+}
+
+type TicketFieldChoice struct {
+	ID             int64               `json:"id"`
+	Label          string              `json:"label"`
+	Value          string              `json:"value"`
+	Position       int                 `json:"position"`
+	ParentChoiceID int64               `json:"parent_choice_id"`
+	Choices        []TicketFieldChoice `json:"choices"`
+}
+
+type DependentField struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	Label             string `json:"label"`
+	LabelForCustomers string `json:"label_for_customers"`
+	Level             int    `json:"level"`
+	TicketFieldID     int64  `json:"ticket_field_id"`
+}
+
+// TicketFieldSection is a group of fields shown when one of ChoiceIDs is
+// selected in the parent field.
+type TicketFieldSection struct {
+	ID                  int64   `json:"id"`
+	Label               string  `json:"label"`
+	ParentTicketFieldID int64   `json:"parent_ticket_field_id"`
+	ChoiceIDs           []int64 `json:"choice_ids"`
+	TicketFieldIDs      []int64 `json:"ticket_field_ids"`
+}
+
+type CreateTicketField struct {
+	Label                string                    `json:"label"`
+	LabelForCustomers    string                    `json:"label_for_customers"`
+	Type                 string                    `json:"type"`
+	Position             int                       `json:"position,omitempty"`
+	RequiredForClosure   bool                      `json:"required_for_closure,omitempty"`
+	RequiredForAgents    bool                      `json:"required_for_agents,omitempty"`
+	RequiredForCustomers bool                      `json:"required_for_customers,omitempty"`
+	CustomersCanEdit     bool                      `json:"customers_can_edit,omitempty"`
+	DisplayedToCustomers bool                      `json:"displayed_to_customers,omitempty"`
+	Choices              []CreateTicketFieldChoice `json:"choices,omitempty"`
+	DependentFields      []CreateDependentField    `json:"dependent_fields,omitempty"`
+}
+
+// CreateTicketFieldChoice is a dropdown or nested field choice. ID is only
+// set to change an existing choice.
+type CreateTicketFieldChoice struct {
+	ID       int64                     `json:"id,omitempty"`
+	Value    string                    `json:"value"`
+	Position int                       `json:"position,omitempty"`
+	Choices  []CreateTicketFieldChoice `json:"choices,omitempty"`
+}
+
+type CreateDependentField struct {
+	Label             string `json:"label"`
+	LabelForCustomers string `json:"label_for_customers"`
+	Level             int    `json:"level"`
+}
+
+// UpdateTicketField is a partial ticket field update; only the fields that
+// are set are sent.
+type UpdateTicketField struct {
+	Label                *string                   `json:"label,omitempty"`
+	LabelForCustomers    *string                   `json:"label_for_customers,omitempty"`
+	Position             *int                      `json:"position,omitempty"`
+	RequiredForClosure   *bool                     `json:"required_for_closure,omitempty"`
+	RequiredForAgents    *bool                     `json:"required_for_agents,omitempty"`
+	RequiredForCustomers *bool                     `json:"required_for_customers,omitempty"`
+	CustomersCanEdit     *bool                     `json:"customers_can_edit,omitempty"`
+	DisplayedToCustomers *bool                     `json:"displayed_to_customers,omitempty"`
+	Choices              []CreateTicketFieldChoice `json:"choices,omitempty"`
+}
+
+// UnmarshalJSON accepts both the admin API's choice objects and the
+// /ticket_fields shapes: lists of strings for dropdowns, nested objects for
+// nested fields and label to value objects for default fields.
+func (field *TicketField) UnmarshalJSON(data []byte) error {
+	type ticketField TicketField
+	aux := struct {
+		*ticketField
+		Choices            json.RawMessage  `json:"choices"`
+		NestedTicketFields []DependentField `json:"nested_ticket_fields"`
+	}{ticketField: (*ticketField)(field)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(field.DependentFields) == 0 {
+		field.DependentFields = aux.NestedTicketFields
+	}
+	var err error
+	field.Choices, err = decodeChoices(aux.Choices, field.Type == "default_status")
+	return err
+}
+
+func decodeChoices(raw json.RawMessage, statusChoices bool) ([]TicketFieldChoice, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	switch raw[0] {
+	case '[':
+		items := []json.RawMessage{}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		choices := []TicketFieldChoice{}
+		for i, item := range items {
+			choice := TicketFieldChoice{}
+			if item[0] == '"' {
+				if err := json.Unmarshal(item, &choice.Value); err != nil {
+					return nil, err
+				}
+				choice.Label = choice.Value
+			} else if err := json.Unmarshal(item, &choice); err != nil {
+				return nil, err
+			}
+			if choice.Label == "" {
+				choice.Label = choice.Value
+			}
+			if choice.Position == 0 {
+				choice.Position = i + 1
+			}
+			choices = append(choices, choice)
+		}
+		return choices, nil
+	case '{':
+		// Objects are decoded token by token to keep the choices in order.
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		choices := []TicketFieldChoice{}
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := token.(string)
+			value := json.RawMessage{}
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+			choice := TicketFieldChoice{Label: key, Value: key, Position: len(choices) + 1}
+			switch {
+			case statusChoices:
+				// Status IDs map to their agent and customer labels.
+				labels := []string{}
+				if err := json.Unmarshal(value, &labels); err == nil && len(labels) > 0 {
+					choice.Label = labels[0]
+				}
+			case value[0] == '[' || value[0] == '{':
+				if choice.Choices, err = decodeChoices(value, false); err != nil {
+					return nil, err
+				}
+			default:
+				// Priorities and sources map labels to values.
+				choice.Value = strings.Trim(string(value), `"`)
+			}
+			choices = append(choices, choice)
+		}
+		return choices, nil
+	}
+	return nil, fmt.Errorf("unexpected ticket field choices %s", raw)
+}
+
+type TicketFieldSlice []TicketField
+
+func (s TicketFieldSlice) Len() int { return len(s) }
+
+func (s TicketFieldSlice) Less(i, j int) bool { return s[i].Position < s[j].Position }
+
+func (s TicketFieldSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s TicketFieldSlice) Print() {
+	for _, field := range s {
+		fmt.Printf("%s (%s)\n", field.Name, field.Type)
+	}
+}
+
+// Field returns the field with the given name, e.g. cf_region.
+func (s TicketFieldSlice) Field(name string) (TicketField, bool) {
+	for _, field := range s {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return TicketField{}, false
+}
+
+// CustomFieldErrors is returned by ValidateCustomFields. It matches
+// ErrValidation and ErrCustomField like the APIError Freshdesk would return.
+type CustomFieldErrors []FieldError
+
+func (e CustomFieldErrors) Error() string {
+	messages := []string{}
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Error())
+	}
+	return "invalid custom fields: " + strings.Join(messages, "; ")
+}
+
+func (e CustomFieldErrors) Is(target error) bool {
+	return target == ErrValidation || target == ErrCustomField
+}
+
+// ValidateCustomFields checks the CustomFields of a ticket against the field
+// definitions: unknown fields, value types, dropdown choices and the levels
+// of nested fields. Required fields are not checked.
+func (s TicketFieldSlice) ValidateCustomFields(customFields map[string]interface{}) error {
+	// Dependent field names map to their nested field and level.
+	levels := map[string]nestedLevel{}
+	for _, field := range s {
+		for _, dependent := range field.DependentFields {
+			levels[dependent.Name] = nestedLevel{field, dependent.Level}
+		}
+	}
+
+	names := make([]string, 0, len(customFields))
+	for name := range customFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errors := CustomFieldErrors{}
+	for _, name := range names {
+		value := customFields[name]
+		if value == nil {
+			continue
+		}
+		if dependent, ok := levels[name]; ok {
+			if message := validateNestedLevel(dependent.field, dependent.level, customFields, levels, value); message != "" {
+				errors = append(errors, FieldError{Field: name, Message: message, Code: "invalid_value"})
+			}
+			continue
+		}
+		field, ok := s.Field(name)
+		if !ok || field.Default {
+			errors = append(errors, FieldError{Field: name, Message: "Unexpected/invalid field in request", Code: "invalid_field"})
+			continue
+		}
+		if message := validateFieldValue(field, value); message != "" {
+			errors = append(errors, FieldError{Field: name, Message: message, Code: "invalid_value"})
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+func validateFieldValue(field TicketField, value interface{}) string {
+	switch field.Type {
+	case FieldTypeText, FieldTypeParagraph:
+		if _, ok := value.(string); !ok {
+			return "It should be a/an String"
+		}
+	case FieldTypeCheckbox:
+		if _, ok := value.(bool); !ok {
+			return "It should be a/an Boolean"
+		}
+	case FieldTypeNumber:
+		if n, ok := toFloat(value); !ok || n != math.Trunc(n) {
+			return "It should be a/an Integer"
+		}
+	case FieldTypeDecimal:
+		if _, ok := toFloat(value); !ok {
+			return "It should be a/an Number"
+		}
+	case FieldTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+		case string:
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return "It should be in the 'yyyy-mm-dd' format"
+			}
+		default:
+			return "It should be in the 'yyyy-mm-dd' format"
+		}
+	case FieldTypeDropdown, FieldTypeNested:
+		if _, ok := findChoice(field.Choices, value); !ok {
+			return "It should be one of these values: '" + strings.Join(choiceValues(field.Choices), ",") + "'"
+		}
+	}
+	return ""
+}
+
+type nestedLevel struct {
+	field TicketField
+	level int
+}
+
+// validateNestedLevel checks the value of the second or third level of a
+// nested field against the choices under the levels above it.
+func validateNestedLevel(field TicketField, depth int, customFields map[string]interface{}, levels map[string]nestedLevel, value interface{}) string {
+	// The value of each level above, starting with the nested field itself.
+	parents := []interface{}{customFields[field.Name]}
+	for l := 2; l < depth; l++ {
+		for name, dependent := range levels {
+			if dependent.field.ID == field.ID && dependent.level == l {
+				parents = append(parents, customFields[name])
+			}
+		}
+	}
+	choices := field.Choices
+	for _, parent := range parents {
+		if parent == nil {
+			return "It should not be set without the levels above it"
+		}
+		choice, ok := findChoice(choices, parent)
+		if !ok {
+			// The level above is reported on its own.
+			return ""
+		}
+		choices = choice.Choices
+	}
+	if _, ok := findChoice(choices, value); !ok {
+		return "It should be one of these values: '" + strings.Join(choiceValues(choices), ",") + "'"
+	}
+	return ""
+}
+
+func findChoice(choices []TicketFieldChoice, value interface{}) (TicketFieldChoice, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return TicketFieldChoice{}, false
+	}
+	for _, choice := range choices {
+		if choice.Value == s {
+			return choice, true
+		}
+	}
+	return TicketFieldChoice{}, false
+}
+
+func choiceValues(choices []TicketFieldChoice) []string {
+	values := []string{}
+	for _, choice := range choices {
+		values = append(values, choice.Value)
+	}
+	return values
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		n, err := strconv.ParseFloat(string(v), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
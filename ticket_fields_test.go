@@ -0,0 +1,207 @@
+package freshdesk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+// choiceTree flattens choices into value paths, e.g. "Spain/Madrid".
+func choiceTree(choices []freshdesk.TicketFieldChoice, prefix string) []string {
+	out := []string{}
+	for _, choice := range choices {
+		path := prefix + choice.Value
+		out = append(out, path+"="+choice.Label)
+		out = append(out, choiceTree(choice.Choices, path+"/")...)
+	}
+	return out
+}
+
+func TestTicketFieldChoices(t *testing.T) {
+	tests := []struct {
+		name, json string
+		want       []string
+	}{
+		{"dropdown", `{"type":"custom_dropdown","choices":["Low","High"]}`, []string{"Low=Low", "High=High"}},
+		{"nested", `{"type":"nested_field","choices":{"Spain":{"Madrid":["Centro","Retiro"],"Seville":[]},"Chile":{}}}`,
+			[]string{"Spain=Spain", "Spain/Madrid=Madrid", "Spain/Madrid/Centro=Centro", "Spain/Madrid/Retiro=Retiro", "Spain/Seville=Seville", "Chile=Chile"}},
+		{"status", `{"type":"default_status","choices":{"2":["Open","Being Processed"],"5":["Closed","This ticket has been Closed"]}}`,
+			[]string{"2=Open", "5=Closed"}},
+		{"priority", `{"type":"default_priority","choices":{"Low":1,"Urgent":4}}`, []string{"1=Low", "4=Urgent"}},
+		{"source", `{"type":"default_source","choices":{"Email":"1","Portal":"2"}}`, []string{"1=Email", "2=Portal"}},
+		{"admin", `{"type":"custom_dropdown","choices":[{"id":7,"value":"a","label":"A","position":2,"choices":[{"id":8,"value":"b","label":"B"}]}]}`,
+			[]string{"a=A", "a/b=B"}},
+		{"none", `{"type":"custom_text","choices":null}`, []string{}},
+	}
+	for _, test := range tests {
+		field := freshdesk.TicketField{}
+		if err := json.Unmarshal([]byte(test.json), &field); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got := choiceTree(field.Choices, ""); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: choices = %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	field := freshdesk.TicketField{}
+	if err := json.Unmarshal([]byte(`{"choices":["a","b"],"nested_ticket_fields":[{"name":"cf_city","level":2}]}`), &field); err != nil {
+		t.Fatal(err)
+	}
+	if field.Choices[1].Position != 2 || len(field.DependentFields) != 1 || field.DependentFields[0].Name != "cf_city" {
+		t.Errorf("field = %+v, want positions and dependent fields filled in", field)
+	}
+	if err := json.Unmarshal([]byte(`{"choices":7}`), &field); err == nil {
+		t.Error("numeric choices were accepted")
+	}
+}
+
+func testFields() freshdesk.TicketFieldSlice {
+	return freshdesk.TicketFieldSlice{
+		{Name: "status", Type: "default_status", Default: true},
+		{Name: "cf_note", Type: freshdesk.FieldTypeText},
+		{Name: "cf_urgent", Type: freshdesk.FieldTypeCheckbox},
+		{Name: "cf_seats", Type: freshdesk.FieldTypeNumber},
+		{Name: "cf_price", Type: freshdesk.FieldTypeDecimal},
+		{Name: "cf_due", Type: freshdesk.FieldTypeDate},
+		{Name: "cf_tier", Type: freshdesk.FieldTypeDropdown, Choices: []freshdesk.TicketFieldChoice{{Value: "Gold"}, {Value: "Silver"}}},
+		{ID: 9, Name: "cf_country", Type: freshdesk.FieldTypeNested,
+			Choices: []freshdesk.TicketFieldChoice{{Value: "Spain", Choices: []freshdesk.TicketFieldChoice{
+				{Value: "Madrid", Choices: []freshdesk.TicketFieldChoice{{Value: "Centro"}}},
+			}}},
+			DependentFields: []freshdesk.DependentField{{Name: "cf_city", Level: 2}, {Name: "cf_district", Level: 3}},
+		},
+	}
+}
+
+func TestValidateCustomFields(t *testing.T) {
+	fields := testFields()
+	valid := map[string]interface{}{
+		"cf_note":     "hello",
+		"cf_urgent":   true,
+		"cf_seats":    12,
+		"cf_price":    9.5,
+		"cf_due":      "2024-02-29",
+		"cf_tier":     "Gold",
+		"cf_country":  "Spain",
+		"cf_city":     "Madrid",
+		"cf_district": "Centro",
+		"cf_unset":    nil,
+	}
+	if err := fields.ValidateCustomFields(valid); err != nil {
+		t.Errorf("valid fields: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		field  string
+		code   string
+	}{
+		{"unknown field", map[string]interface{}{"cf_missing": "x"}, "cf_missing", "invalid_field"},
+		{"default field", map[string]interface{}{"status": 2}, "status", "invalid_field"},
+		{"text", map[string]interface{}{"cf_note": 5}, "cf_note", "invalid_value"},
+		{"checkbox", map[string]interface{}{"cf_urgent": "yes"}, "cf_urgent", "invalid_value"},
+		{"number", map[string]interface{}{"cf_seats": 1.5}, "cf_seats", "invalid_value"},
+		{"decimal", map[string]interface{}{"cf_price": "9.5"}, "cf_price", "invalid_value"},
+		{"date", map[string]interface{}{"cf_due": "29/02/2024"}, "cf_due", "invalid_value"},
+		{"dropdown", map[string]interface{}{"cf_tier": "Bronze"}, "cf_tier", "invalid_value"},
+		{"second level", map[string]interface{}{"cf_country": "Spain", "cf_city": "Paris"}, "cf_city", "invalid_value"},
+		{"third level", map[string]interface{}{"cf_country": "Spain", "cf_city": "Madrid", "cf_district": "Retiro"}, "cf_district", "invalid_value"},
+		{"level without parent", map[string]interface{}{"cf_city": "Madrid"}, "cf_city", "invalid_value"},
+	}
+	for _, test := range tests {
+		err := fields.ValidateCustomFields(test.fields)
+		fieldErrors := freshdesk.CustomFieldErrors{}
+		if !errors.As(err, &fieldErrors) || len(fieldErrors) != 1 {
+			t.Errorf("%s: err = %v, want one field error", test.name, err)
+			continue
+		}
+		if fieldErrors[0].Field != test.field || fieldErrors[0].Code != test.code {
+			t.Errorf("%s: got %s %s, want %s %s", test.name, fieldErrors[0].Field, fieldErrors[0].Code, test.field, test.code)
+		}
+		if !freshdesk.IsValidationError(err) || !freshdesk.IsCustomFieldError(err) {
+			t.Errorf("%s: %v does not match ErrValidation and ErrCustomField", test.name, err)
+		}
+	}
+
+	// An invalid nested field reports its own level only.
+	err := fields.ValidateCustomFields(map[string]interface{}{"cf_country": "France", "cf_city": "Paris"})
+	fieldErrors := freshdesk.CustomFieldErrors{}
+	if !errors.As(err, &fieldErrors) || len(fieldErrors) != 1 || fieldErrors[0].Field != "cf_country" {
+		t.Errorf("err = %v, want only cf_country", err)
+	}
+}
+
+func TestTicketFieldAdmin(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddTicketField(freshdesk.TicketField{Name: "status", Type: "default_status", Default: true, Position: 1})
+	client := server.Client(nil)
+
+	created, err := client.Admin.CreateTicketField(freshdesk.CreateTicketField{
+		Label:             "Country",
+		LabelForCustomers: "Country",
+		Type:              freshdesk.FieldTypeNested,
+		Choices: []freshdesk.CreateTicketFieldChoice{{Value: "Spain", Choices: []freshdesk.CreateTicketFieldChoice{
+			{Value: "Madrid", Choices: []freshdesk.CreateTicketFieldChoice{{Value: "Centro"}}},
+		}}},
+		DependentFields: []freshdesk.CreateDependentField{
+			{Label: "City", LabelForCustomers: "City", Level: 2},
+			{Label: "District", LabelForCustomers: "District", Level: 3},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Name != "cf_country" || len(created.DependentFields) != 2 || created.DependentFields[0].Name != "cf_city" {
+		t.Errorf("created field = %+v", created)
+	}
+	madrid := created.Choices[0].Choices[0]
+	if madrid.ID == 0 || madrid.ParentChoiceID != created.Choices[0].ID || madrid.Label != "Madrid" {
+		t.Errorf("second level choice = %+v", madrid)
+	}
+
+	if _, err := client.Admin.CreateTicketField(freshdesk.CreateTicketField{Label: "Country", LabelForCustomers: "Country", Type: freshdesk.FieldTypeText}); !freshdesk.IsValidationError(err) {
+		t.Errorf("duplicate label: err = %v, want a validation error", err)
+	}
+	updated, err := client.Admin.UpdateTicketField(created.ID, freshdesk.UpdateTicketField{Label: freshdesk.Ptr("Country of residence")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Label != "Country of residence" || updated.Name != "cf_country" {
+		t.Errorf("updated field = %+v", updated)
+	}
+
+	fields, err := client.Admin.TicketFields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || fields[0].Name != "status" {
+		t.Errorf("fields = %v", fields)
+	}
+	// Tickets are validated against the stored fields.
+	ticket := freshdesk.CreateTicket{Email: "a@example.com", Subject: "Move", Status: 2, Priority: 1,
+		CustomFields: map[string]interface{}{"cf_country": "Spain", "cf_city": "Paris"}}
+	if _, err := client.Tickets.Create(ticket); !freshdesk.IsCustomFieldError(err) {
+		t.Errorf("invalid city: err = %v, want a custom field error", err)
+	}
+	ticket.CustomFields["cf_city"] = "Madrid"
+	if _, err := client.Tickets.Create(ticket); err != nil {
+		t.Errorf("valid custom fields: %v", err)
+	}
+
+	if err := client.Admin.DeleteTicketField(fields[0].ID); !freshdesk.IsForbidden(err) {
+		t.Errorf("deleting a default field: err = %v, want forbidden", err)
+	}
+	if err := client.Admin.DeleteTicketField(created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Admin.ViewTicketField(created.ID); !freshdesk.IsNotFound(err) {
+		t.Errorf("after delete: err = %v, want not found", err)
+	}
+}
@@ -1,10 +1,14 @@
 package freshdesk
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
@@ -13,12 +17,43 @@ import (
 
 type TicketManager interface {
 	All() (TicketResults, error)
+	AllContext(context.Context) (TicketResults, error)
+	List(*ListOptions) *Iterator[Ticket]
+	ListWithOptions(*ListTicketsOptions) *Iterator[Ticket]
 	Create(CreateTicket) (Ticket, error)
+	CreateContext(context.Context, CreateTicket) (Ticket, error)
 	View(int64) (Ticket, error)
+	ViewContext(context.Context, int64) (Ticket, error)
+	Update(int64, UpdateTicket) (Ticket, error)
+	UpdateContext(context.Context, int64, UpdateTicket) (Ticket, error)
+	Delete(int64) error
+	DeleteContext(context.Context, int64) error
+	Restore(int64) error
+	RestoreContext(context.Context, int64) error
+	BulkUpdate([]int64, UpdateTicket) (string, error)
+	BulkUpdateContext(context.Context, []int64, UpdateTicket) (string, error)
+	BulkDelete([]int64) (string, error)
+	BulkDeleteContext(context.Context, []int64) (string, error)
 	Search(querybuilder.Query) (TicketResults, error)
+	SearchContext(context.Context, querybuilder.Query) (TicketResults, error)
+	SearchIter(querybuilder.Query) *Iterator[Ticket]
+	SearchAll(querybuilder.Query) (TicketResults, error)
+	SearchAllContext(context.Context, querybuilder.Query) (TicketResults, error)
 	Reply(int64, CreateReply) (Reply, error)
+	ReplyContext(context.Context, int64, CreateReply) (Reply, error)
+	AddNote(int64, CreateNote) (Conversation, error)
+	AddNoteContext(context.Context, int64, CreateNote) (Conversation, error)
+	Forward(int64, Forward) (Conversation, error)
+	ForwardContext(context.Context, int64, Forward) (Conversation, error)
+	Merge(int64, []int64, MergeNotes) error
+	MergeContext(context.Context, int64, []int64, MergeNotes) error
+	CreateOutboundEmail(CreateOutboundEmail) (Ticket, error)
+	CreateOutboundEmailContext(context.Context, CreateOutboundEmail) (Ticket, error)
 	Conversations(int64) (ConversationSlice, error)
+	ConversationsContext(context.Context, int64) (ConversationSlice, error)
+	ListConversations(int64, *ListOptions) *Iterator[Conversation]
 	UpdatedSinceAll(string) (TicketResults, error)
+	UpdatedSinceAllContext(context.Context, string) (TicketResults, error)
 }
 
 type ticketManager struct {
@@ -28,7 +63,12 @@ type ticketManager struct {
 type TicketResults struct {
 	next    string
 	Results TicketSlice
-	client  *ApiClient
+	// Total is the number of tickets matching a search.
+	Total int
+	// Truncated is set when a search matched more tickets than Freshdesk
+	// returns for a single query; see SearchAll.
+	Truncated bool
+	client    *ApiClient
 }
 
 func newTicketManager(client *ApiClient) ticketManager {
@@ -43,7 +83,7 @@ type Ticket struct {
 	Subject                string                 `bson:"subjecte" json:"subject"`
 	Type                   string                 `bson:"type" json:"type"`
 	Description            string                 `bson:"description" json:"description"`
-	Attachments            []interface{}          `bson:"attachments" json:"attachments"`
+	Attachments            []Attachment           `bson:"attachments" json:"attachments"`
 	CCEmails               []string               `bson:"cc_emails" json:"cc_emails"`
 	CompanyID              int64                  `bson:"company_id" json:"company_id"`
 	Deleted                bool                   `bson:"deleted" json:"deleted"`
@@ -74,6 +114,102 @@ type Ticket struct {
 	UpdatedAt              *time.Time             `bson:"updated_at" json:"updated_at"`
 	CustomFields           map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
 	Conversations          []Conversation         `bson:"-" json:"conversations"`
+	// Requester, Stats and Company are only set when requested with Include.
+	Requester *TicketRequester `bson:"requester,omitempty" json:"requester,omitempty"`
+	Stats     *TicketStats     `bson:"stats,omitempty" json:"stats,omitempty"`
+	Company   *TicketCompany   `bson:"company,omitempty" json:"company,omitempty"`
+}
+
+type TicketRequester struct {
+	ID     int64  `bson:"id" json:"id"`
+	Name   string `bson:"name" json:"name"`
+	Email  string `bson:"email" json:"email"`
+	Mobile string `bson:"mobile" json:"mobile"`
+	Phone  string `bson:"phone" json:"phone"`
+}
+
+type TicketStats struct {
+	AgentRespondedAt     *time.Time `bson:"agent_responded_at" json:"agent_responded_at"`
+	RequesterRespondedAt *time.Time `bson:"requester_responded_at" json:"requester_responded_at"`
+	FirstRespondedAt     *time.Time `bson:"first_responded_at" json:"first_responded_at"`
+	StatusUpdatedAt      *time.Time `bson:"status_updated_at" json:"status_updated_at"`
+	ReopenedAt           *time.Time `bson:"reopened_at" json:"reopened_at"`
+	ResolvedAt           *time.Time `bson:"resolved_at" json:"resolved_at"`
+	ClosedAt             *time.Time `bson:"closed_at" json:"closed_at"`
+	PendingSince         *time.Time `bson:"pending_since" json:"pending_since"`
+}
+
+type TicketCompany struct {
+	ID   int64  `bson:"id" json:"id"`
+	Name string `bson:"name" json:"name"`
+}
+
+// Predefined ticket list filters.
+const (
+	TicketFilterNewAndMyOpen = "new_and_my_open"
+	TicketFilterWatching     = "watching"
+	TicketFilterSpam         = "spam"
+	TicketFilterDeleted      = "deleted"
+)
+
+// Objects that can be embedded in listed tickets.
+const (
+	IncludeRequester   = "requester"
+	IncludeStats       = "stats"
+	IncludeDescription = "description"
+	IncludeCompany     = "company"
+)
+
+// ListTicketsOptions filters, orders and embeds listed tickets. Zero fields
+// are left out of the request.
+type ListTicketsOptions struct {
+	ListOptions
+	// Filter is one of the predefined filters, e.g. TicketFilterSpam.
+	Filter       string
+	RequesterID  int64
+	CompanyID    int64
+	Email        string
+	UpdatedSince time.Time
+	// OrderBy is created_at, due_by, updated_at or status.
+	OrderBy string
+	// OrderType is asc or desc.
+	OrderType string
+	Include   []string
+}
+
+func (options *ListTicketsOptions) apply(path string) string {
+	if options == nil {
+		return path
+	}
+	query := url.Values{}
+	if options.Filter != "" {
+		query.Set("filter", options.Filter)
+	}
+	if options.RequesterID != 0 {
+		query.Set("requester_id", strconv.FormatInt(options.RequesterID, 10))
+	}
+	if options.CompanyID != 0 {
+		query.Set("company_id", strconv.FormatInt(options.CompanyID, 10))
+	}
+	if options.Email != "" {
+		query.Set("email", options.Email)
+	}
+	if !options.UpdatedSince.IsZero() {
+		query.Set("updated_since", options.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	if options.OrderBy != "" {
+		query.Set("order_by", options.OrderBy)
+	}
+	if options.OrderType != "" {
+		query.Set("order_type", options.OrderType)
+	}
+	if len(options.Include) > 0 {
+		query.Set("include", strings.Join(options.Include, ","))
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
 }
 
 type CreateTicket struct {
@@ -90,7 +226,7 @@ type CreateTicket struct {
 	Priority           int                    `json:"priority,omitempty"`
 	Description        string                 `json:"description,omitempty"`
 	ResponderID        int                    `json:"responder_id,omitempty"`
-	Attachments        []interface{}          `json:"attachments,omitempty"`
+	Attachments        []File                 `json:"-"`
 	CCEmails           []string               `json:"cc_emails,omitempty"`
 	CustomFields       map[string]interface{} `json:"custom_fields,omitempty"`
 	DueBy              *time.Time             `json:"due_by,omitempty"`
@@ -103,6 +239,40 @@ type CreateTicket struct {
 	CompanyID          int                    `json:"company_id,omitempty"`
 }
 
+// UpdateTicket is a partial ticket update: only the fields that are set are
+// sent, so a field can be set to its zero value. Ptr helps filling it in:
+//
+//	client.Tickets.Update(id, freshdesk.UpdateTicket{Status: freshdesk.Ptr(freshdesk.StatusClosed.Value())})
+type UpdateTicket struct {
+	Name               *string                `json:"name,omitempty"`
+	RequesterID        *int64                 `json:"requester_id,omitempty"`
+	Email              *string                `json:"email,omitempty"`
+	FacebookID         *string                `json:"facebook_id,omitempty"`
+	Phone              *string                `json:"phone,omitempty"`
+	TwitterID          *string                `json:"twitter_id,omitempty"`
+	UniqueExternalID   *string                `json:"unique_external_id,omitempty"`
+	Subject            *string                `json:"subject,omitempty"`
+	Type               *string                `json:"type,omitempty"`
+	Status             *int                   `json:"status,omitempty"`
+	Priority           *int                   `json:"priority,omitempty"`
+	Description        *string                `json:"description,omitempty"`
+	ResponderID        *int64                 `json:"responder_id,omitempty"`
+	DueBy              *time.Time             `json:"due_by,omitempty"`
+	EmailConfigID      *int64                 `json:"email_config_id,omitempty"`
+	FirstResponseDueBy *time.Time             `json:"fr_due_by,omitempty"`
+	GroupID            *int64                 `json:"group_id,omitempty"`
+	ProductID          *int64                 `json:"product_id,omitempty"`
+	Source             *int                   `json:"source,omitempty"`
+	Tags               *[]string              `json:"tags,omitempty"`
+	CompanyID          *int64                 `json:"company_id,omitempty"`
+	CustomFields       map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// Ptr returns a pointer to v, for the fields of partial updates.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
 type Conversation struct {
 	mgm.DefaultModel `bson:",inline" json:"-"`
 	ID               int64      `bson:"id" json:"id"`
@@ -121,32 +291,90 @@ type Conversation struct {
 	CCEmails         []string   `bson:"cc_emails" json:"cc_emails"`
 	BCCEmails        []string   `bson:"bcc_emails" json:"bcc_emails"`
 
-	Attachments []interface{} `json:"attachments"`
+	Attachments []Attachment `json:"attachments"`
 }
 
 type Reply struct {
-	BodyText    string        `json:"body_text"`
-	Body        string        `json:"body"`
-	ID          int           `json:"id"`
-	UserID      int           `json:"user_id"`
-	FromEmail   string        `json:"from_email"`
-	CCEmails    []string      `json:"cc_emails"`
-	BCCEmails   []string      `json:"bcc_emails"`
-	ToEmails    []string      `json:"to_emails"`
-	TicketID    int           `json:"ticket_id"`
-	RepliedTo   []string      `json:"replied_to"`
-	Attachments []interface{} `json:"attachments"`
-	CreatedAt   *time.Time    `json:"created_at"`
-	UpdatedAt   *time.Time    `json:"updated_at"`
+	BodyText    string       `json:"body_text"`
+	Body        string       `json:"body"`
+	ID          int          `json:"id"`
+	UserID      int          `json:"user_id"`
+	FromEmail   string       `json:"from_email"`
+	CCEmails    []string     `json:"cc_emails"`
+	BCCEmails   []string     `json:"bcc_emails"`
+	ToEmails    []string     `json:"to_emails"`
+	TicketID    int          `json:"ticket_id"`
+	RepliedTo   []string     `json:"replied_to"`
+	Attachments []Attachment `json:"attachments"`
+	CreatedAt   *time.Time   `json:"created_at"`
+	UpdatedAt   *time.Time   `json:"updated_at"`
 }
 
 type CreateReply struct {
-	Body        string        `json:"body,omitempty"`
-	FromEmail   string        `json:"from_email,omitempty"`
-	Attachments []interface{} `json:"attachments,omitempty"`
-	UserID      int           `json:"user_id,omitempty"`
-	CCEmails    []string      `json:"cc_emails,omitempty"`
-	BCCEmails   []string      `json:"bcc_emails,omitempty"`
+	Body        string   `json:"body,omitempty"`
+	FromEmail   string   `json:"from_email,omitempty"`
+	Attachments []File   `json:"-"`
+	UserID      int      `json:"user_id,omitempty"`
+	CCEmails    []string `json:"cc_emails,omitempty"`
+	BCCEmails   []string `json:"bcc_emails,omitempty"`
+}
+
+type CreateNote struct {
+	Body string `json:"body"`
+	// Private defaults to true; set it to false for a note the requester
+	// can see.
+	Private      *bool    `json:"private,omitempty"`
+	Incoming     bool     `json:"incoming,omitempty"`
+	NotifyEmails []string `json:"notify_emails,omitempty"`
+	UserID       int64    `json:"user_id,omitempty"`
+	Attachments  []File   `json:"-"`
+}
+
+type Forward struct {
+	Body                       string   `json:"body,omitempty"`
+	ToEmails                   []string `json:"to_emails"`
+	CCEmails                   []string `json:"cc_emails,omitempty"`
+	BCCEmails                  []string `json:"bcc_emails,omitempty"`
+	FromEmail                  string   `json:"from_email,omitempty"`
+	AgentID                    int64    `json:"agent_id,omitempty"`
+	IncludeQuotedText          *bool    `json:"include_quoted_text,omitempty"`
+	IncludeOriginalAttachments *bool    `json:"include_original_attachments,omitempty"`
+	Attachments                []File   `json:"-"`
+}
+
+// MergeNotes are the notes added to the primary and secondary tickets of a
+// merge. Freshdesk adds default notes for the ones left nil.
+type MergeNotes struct {
+	Primary               *MergeNote `json:"note_in_primary,omitempty"`
+	Secondary             *MergeNote `json:"note_in_secondary,omitempty"`
+	ConvertRecipientsToCC bool       `json:"convert_recepients_to_cc,omitempty"`
+}
+
+type MergeNote struct {
+	Body    string `json:"body"`
+	Private bool   `json:"private"`
+}
+
+// CreateOutboundEmail is a new ticket started by an email to a customer.
+// Email, Subject, Description and EmailConfigID are required.
+type CreateOutboundEmail struct {
+	Name               string                 `json:"name,omitempty"`
+	Email              string                 `json:"email"`
+	Subject            string                 `json:"subject"`
+	Type               string                 `json:"type,omitempty"`
+	Status             int                    `json:"status,omitempty"`
+	Priority           int                    `json:"priority,omitempty"`
+	Description        string                 `json:"description"`
+	EmailConfigID      int64                  `json:"email_config_id"`
+	ResponderID        int64                  `json:"responder_id,omitempty"`
+	GroupID            int64                  `json:"group_id,omitempty"`
+	CompanyID          int64                  `json:"company_id,omitempty"`
+	CCEmails           []string               `json:"cc_emails,omitempty"`
+	Tags               []string               `json:"tags,omitempty"`
+	DueBy              *time.Time             `json:"due_by,omitempty"`
+	FirstResponseDueBy *time.Time             `json:"fr_due_by,omitempty"`
+	CustomFields       map[string]interface{} `json:"custom_fields,omitempty"`
+	Attachments        []File                 `json:"-"`
 }
 
 type Source int
@@ -230,8 +458,13 @@ func (s ConversationSlice) Print() {
 }
 
 func (manager ticketManager) All() (TicketResults, error) {
+	return manager.AllContext(context.Background())
+}
+
+func (manager ticketManager) AllContext(ctx context.Context) (TicketResults, error) {
+	ctx = withOperation(ctx, "tickets.list")
 	output := TicketSlice{}
-	headers, err := manager.client.get(endpoints.tickets.all, &output)
+	headers, err := manager.client.get(ctx, endpoints.tickets.all, &output)
 	if err != nil {
 		return TicketResults{}, err
 	}
@@ -242,9 +475,28 @@ func (manager ticketManager) All() (TicketResults, error) {
 	}, nil
 }
 
+func (manager ticketManager) List(options *ListOptions) *Iterator[Ticket] {
+	return newLinkIterator[Ticket](manager.client, endpoints.tickets.all, options, "tickets.list", nil)
+}
+
+func (manager ticketManager) ListWithOptions(options *ListTicketsOptions) *Iterator[Ticket] {
+	path := endpoints.tickets.all
+	var listOptions *ListOptions
+	if options != nil {
+		path = options.apply(path)
+		listOptions = &options.ListOptions
+	}
+	return newLinkIterator[Ticket](manager.client, path, listOptions, "tickets.list", nil)
+}
+
 func (manager ticketManager) UpdatedSinceAll(timeString string) (TicketResults, error) {
+	return manager.UpdatedSinceAllContext(context.Background(), timeString)
+}
+
+func (manager ticketManager) UpdatedSinceAllContext(ctx context.Context, timeString string) (TicketResults, error) {
+	ctx = withOperation(ctx, "tickets.list")
 	output := TicketSlice{}
-	headers, err := manager.client.get(endpoints.tickets.updatedSinceAll(timeString), &output)
+	headers, err := manager.client.get(ctx, endpoints.tickets.updatedSinceAll(timeString), &output)
 	if err != nil {
 		return TicketResults{}, err
 	}
@@ -256,12 +508,17 @@ func (manager ticketManager) UpdatedSinceAll(timeString string) (TicketResults,
 }
 
 func (manager ticketManager) Create(ticket CreateTicket) (Ticket, error) {
+	return manager.CreateContext(context.Background(), ticket)
+}
+
+func (manager ticketManager) CreateContext(ctx context.Context, ticket CreateTicket) (Ticket, error) {
+	ctx = withOperation(ctx, "tickets.create")
 	output := Ticket{}
-	jsonb, err := json.Marshal(ticket)
+	body, contentType, err := encodeBody(ticket, ticket.Attachments)
 	if err != nil {
 		return output, err
 	}
-	err = manager.client.postJSON(endpoints.tickets.create, jsonb, &output, http.StatusCreated)
+	err = manager.client.sendBody(ctx, http.MethodPost, endpoints.tickets.create, body, contentType, &output, http.StatusCreated)
 	if err != nil {
 		return Ticket{}, err
 	}
@@ -269,8 +526,13 @@ func (manager ticketManager) Create(ticket CreateTicket) (Ticket, error) {
 }
 
 func (manager ticketManager) View(id int64) (Ticket, error) {
+	return manager.ViewContext(context.Background(), id)
+}
+
+func (manager ticketManager) ViewContext(ctx context.Context, id int64) (Ticket, error) {
+	ctx = withOperation(ctx, "tickets.view")
 	output := Ticket{}
-	_, err := manager.client.get(endpoints.tickets.view(id), &output)
+	_, err := manager.client.get(ctx, endpoints.tickets.view(id), &output)
 	if err != nil {
 		return Ticket{}, err
 	}
@@ -278,72 +540,256 @@ func (manager ticketManager) View(id int64) (Ticket, error) {
 	return output, nil
 }
 
+func (manager ticketManager) Update(id int64, ticket UpdateTicket) (Ticket, error) {
+	return manager.UpdateContext(context.Background(), id, ticket)
+}
+
+// UpdateContext updates the fields set in ticket. Validation failures are
+// APIErrors matching ErrValidation, and ErrCustomField when a custom field
+// was rejected.
+func (manager ticketManager) UpdateContext(ctx context.Context, id int64, ticket UpdateTicket) (Ticket, error) {
+	ctx = withOperation(ctx, "tickets.update")
+	output := Ticket{}
+	jsonb, err := json.Marshal(ticket)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.put(ctx, endpoints.tickets.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Ticket{}, err
+	}
+	return output, nil
+}
+
+func (manager ticketManager) Delete(id int64) error {
+	return manager.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext moves the ticket to the trash, from which Restore recovers it.
+func (manager ticketManager) DeleteContext(ctx context.Context, id int64) error {
+	ctx = withOperation(ctx, "tickets.delete")
+	return manager.client.delete(ctx, endpoints.tickets.delete(id), http.StatusNoContent)
+}
+
+func (manager ticketManager) Restore(id int64) error {
+	return manager.RestoreContext(context.Background(), id)
+}
+
+func (manager ticketManager) RestoreContext(ctx context.Context, id int64) error {
+	ctx = withOperation(ctx, "tickets.restore")
+	return manager.client.put(ctx, endpoints.tickets.restore(id), nil, nil, http.StatusNoContent)
+}
+
+func (manager ticketManager) BulkUpdate(ids []int64, properties UpdateTicket) (string, error) {
+	return manager.BulkUpdateContext(context.Background(), ids, properties)
+}
+
+// BulkUpdateContext applies properties to every ticket in the background and
+// returns the job ID to pass to Jobs.Wait.
+func (manager ticketManager) BulkUpdateContext(ctx context.Context, ids []int64, properties UpdateTicket) (string, error) {
+	ctx = withOperation(ctx, "tickets.bulk_update")
+	return manager.bulkAction(ctx, endpoints.tickets.bulkUpdate, map[string]interface{}{
+		"ids":        ids,
+		"properties": properties,
+	})
+}
+
+func (manager ticketManager) BulkDelete(ids []int64) (string, error) {
+	return manager.BulkDeleteContext(context.Background(), ids)
+}
+
+// BulkDeleteContext deletes every ticket in the background and returns the
+// job ID to pass to Jobs.Wait.
+func (manager ticketManager) BulkDeleteContext(ctx context.Context, ids []int64) (string, error) {
+	ctx = withOperation(ctx, "tickets.bulk_delete")
+	return manager.bulkAction(ctx, endpoints.tickets.bulkDelete, map[string]interface{}{
+		"ids": ids,
+	})
+}
+
+func (manager ticketManager) bulkAction(ctx context.Context, path string, action map[string]interface{}) (string, error) {
+	output := struct {
+		JobID string `json:"job_id"`
+	}{}
+	jsonb, err := json.Marshal(map[string]interface{}{"bulk_action": action})
+	if err != nil {
+		return "", err
+	}
+	err = manager.client.postJSON(ctx, path, jsonb, &output, http.StatusAccepted)
+	if err != nil {
+		return "", err
+	}
+	return output.JobID, nil
+}
+
 func (manager ticketManager) Conversations(id int64) (ConversationSlice, error) {
-	output := ConversationSlice{}
-	_, err := manager.client.get(endpoints.tickets.conversations(id), &output)
+	return manager.ConversationsContext(context.Background(), id)
+}
+
+func (manager ticketManager) ConversationsContext(ctx context.Context, id int64) (ConversationSlice, error) {
+	output, err := manager.ListConversations(id, nil).All(ctx)
 	if err != nil {
 		return ConversationSlice{}, err
 	}
 	return output, nil
 }
 
+func (manager ticketManager) ListConversations(id int64, options *ListOptions) *Iterator[Conversation] {
+	return newConversationManager(manager.client).List(id, options)
+}
+
 func (manager ticketManager) Reply(id int64, reply CreateReply) (Reply, error) {
+	return manager.ReplyContext(context.Background(), id, reply)
+}
+
+func (manager ticketManager) ReplyContext(ctx context.Context, id int64, reply CreateReply) (Reply, error) {
+	ctx = withOperation(ctx, "tickets.reply")
 	output := Reply{}
-	jsonb, err := json.Marshal(reply)
+	body, contentType, err := encodeBody(reply, reply.Attachments)
 	if err != nil {
 		return output, err
 	}
-	err = manager.client.postJSON(endpoints.tickets.reply(id), jsonb, &output, http.StatusCreated)
+	err = manager.client.sendBody(ctx, http.MethodPost, endpoints.tickets.reply(id), body, contentType, &output, http.StatusCreated)
 	if err != nil {
 		return Reply{}, err
 	}
 	return output, nil
 }
 
+func (manager ticketManager) AddNote(id int64, note CreateNote) (Conversation, error) {
+	return manager.AddNoteContext(context.Background(), id, note)
+}
+
+func (manager ticketManager) AddNoteContext(ctx context.Context, id int64, note CreateNote) (Conversation, error) {
+	ctx = withOperation(ctx, "tickets.notes.create")
+	output := Conversation{}
+	body, contentType, err := encodeBody(note, note.Attachments)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.sendBody(ctx, http.MethodPost, endpoints.tickets.notes(id), body, contentType, &output, http.StatusCreated)
+	if err != nil {
+		return Conversation{}, err
+	}
+	return output, nil
+}
+
+func (manager ticketManager) Forward(id int64, forward Forward) (Conversation, error) {
+	return manager.ForwardContext(context.Background(), id, forward)
+}
+
+func (manager ticketManager) ForwardContext(ctx context.Context, id int64, forward Forward) (Conversation, error) {
+	ctx = withOperation(ctx, "tickets.forward")
+	output := Conversation{}
+	body, contentType, err := encodeBody(forward, forward.Attachments)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.sendBody(ctx, http.MethodPost, endpoints.tickets.forward(id), body, contentType, &output, http.StatusCreated)
+	if err != nil {
+		return Conversation{}, err
+	}
+	return output, nil
+}
+
+func (manager ticketManager) Merge(primaryID int64, secondaryIDs []int64, notes MergeNotes) error {
+	return manager.MergeContext(context.Background(), primaryID, secondaryIDs, notes)
+}
+
+// MergeContext merges the secondary tickets into the primary one and closes
+// them. A merge that fails with a 5xx response or network error may still
+// have been applied, so it is not retried; check the tickets before merging
+// again.
+func (manager ticketManager) MergeContext(ctx context.Context, primaryID int64, secondaryIDs []int64, notes MergeNotes) error {
+	ctx = withOperation(ctx, "tickets.merge")
+	jsonb, err := json.Marshal(struct {
+		PrimaryID int64   `json:"primary_id"`
+		TicketIDs []int64 `json:"ticket_ids"`
+		MergeNotes
+	}{primaryID, secondaryIDs, notes})
+	if err != nil {
+		return err
+	}
+	return manager.client.put(sendOnce(ctx), endpoints.tickets.merge, jsonb, nil, http.StatusNoContent)
+}
+
+func (manager ticketManager) CreateOutboundEmail(email CreateOutboundEmail) (Ticket, error) {
+	return manager.CreateOutboundEmailContext(context.Background(), email)
+}
+
+func (manager ticketManager) CreateOutboundEmailContext(ctx context.Context, email CreateOutboundEmail) (Ticket, error) {
+	ctx = withOperation(ctx, "tickets.outbound_email")
+	output := Ticket{}
+	body, contentType, err := encodeBody(email, email.Attachments)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.sendBody(ctx, http.MethodPost, endpoints.tickets.outboundEmail, body, contentType, &output, http.StatusCreated)
+	if err != nil {
+		return Ticket{}, err
+	}
+	return output, nil
+}
+
 func (manager ticketManager) Search(query querybuilder.Query) (TicketResults, error) {
-	output := struct {
-		Slice TicketSlice `json:"results"`
-		Total int         `json:"total"`
-	}{}
-	_, err := manager.client.get(endpoints.tickets.search(query.URLSafe()), &output)
+	return manager.SearchContext(context.Background(), query)
+}
+
+func (manager ticketManager) SearchContext(ctx context.Context, query querybuilder.Query) (TicketResults, error) {
+	total := 0
+	output, err := newSearchIterator[Ticket](manager.client, "tickets", endpoints.tickets.search(query.URLSafe()), &total).All(ctx)
 	if err != nil {
 		return TicketResults{}, err
 	}
+	return TicketResults{
+		Results:   output,
+		Total:     total,
+		Truncated: len(output) < total,
+		client:    manager.client,
+	}, nil
+}
 
-	page := 1
-	for {
-		if len(output.Slice) >= output.Total || page == 10 {
-			break
-		}
-		page++
-		nextSlice := struct {
-			Slice TicketSlice `json:"results"`
-			Total int         `json:"total"`
-		}{}
-		_, err := manager.client.get(
-			fmt.Sprintf("%s&page=%d", endpoints.tickets.search(query.URLSafe()), page),
-			&nextSlice,
-		)
-		if err != nil {
-			break
-		}
+// SearchIter streams the tickets matching query, fetching 30 at a time. It
+// ends after the first 300; SearchAll returns every match.
+func (manager ticketManager) SearchIter(query querybuilder.Query) *Iterator[Ticket] {
+	return newSearchIterator[Ticket](manager.client, "tickets", endpoints.tickets.search(query.URLSafe()), nil)
+}
 
-		output.Slice = append(output.Slice, nextSlice.Slice...)
-		output.Total = nextSlice.Total
-	}
+func (manager ticketManager) SearchAll(query querybuilder.Query) (TicketResults, error) {
+	return manager.SearchAllContext(context.Background(), query)
+}
 
+// SearchAllContext returns every ticket matching query, splitting the search
+// into date windows when it matches more than the 300 tickets Freshdesk
+// returns for a single query. Truncated is only set if a single day still
+// holds too many tickets.
+func (manager ticketManager) SearchAllContext(ctx context.Context, query querybuilder.Query) (TicketResults, error) {
+	ctx = withOperation(ctx, "tickets.search")
+	output, truncated, err := searchPartitioned(ctx, manager.client, endpoints.tickets.search, query, func(ticket Ticket) int64 {
+		return ticket.ID
+	})
+	if err != nil {
+		return TicketResults{}, err
+	}
 	return TicketResults{
-		Results: output.Slice,
-		client:  manager.client,
+		Results:   output,
+		Total:     len(output),
+		Truncated: truncated,
+		client:    manager.client,
 	}, nil
 }
 
 func (results TicketResults) Next() (TicketResults, error) {
+	return results.NextContext(context.Background())
+}
+
+func (results TicketResults) NextContext(ctx context.Context) (TicketResults, error) {
+	ctx = withOperation(ctx, "tickets.list")
 	if results.next == "" {
 		return TicketResults{}, errors.New("no more tickets")
 	}
 	output := TicketSlice{}
-	headers, err := results.client.get(results.next, &output)
+	headers, err := results.client.get(ctx, results.next, &output)
 	if err != nil {
 		return TicketResults{}, err
 	}
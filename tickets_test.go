@@ -0,0 +1,210 @@
+package freshdesk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestUpdateTicketSendsOnlySetFields(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Printer", Status: 2, Priority: 3, Tags: []string{"hardware"}})
+	client := server.Client(nil)
+
+	// Pointers to zero values clear a field, nil pointers leave it alone.
+	updated, err := client.Tickets.Update(ticket.ID, freshdesk.UpdateTicket{
+		Subject: freshdesk.Ptr(""),
+		Tags:    freshdesk.Ptr([]string{}),
+		Status:  freshdesk.Ptr(4),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Subject != "" || len(updated.Tags) != 0 || updated.Status != 4 || updated.Priority != 3 {
+		t.Errorf("updated to subject %q, tags %v, status %d, priority %d", updated.Subject, updated.Tags, updated.Status, updated.Priority)
+	}
+
+	var sent map[string]interface{}
+	requests := server.Requests()
+	if err := json.Unmarshal(requests[len(requests)-1].Body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 3 || sent["subject"] != "" || sent["status"] != float64(4) {
+		t.Errorf("sent %v, want only subject, tags and status", sent)
+	}
+	if tags, ok := sent["tags"].([]interface{}); !ok || len(tags) != 0 {
+		t.Errorf("sent tags %v, want an empty list", sent["tags"])
+	}
+}
+
+func TestUpdateTicketIsValidated(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddTicketField(freshdesk.TicketField{Name: "cf_tier", Type: freshdesk.FieldTypeDropdown,
+		Choices: []freshdesk.TicketFieldChoice{{Value: "Gold"}, {Value: "Silver"}}})
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Printer", Status: 2, Priority: 1,
+		CustomFields: map[string]interface{}{"cf_tier": "Gold"}})
+	client := server.Client(nil)
+
+	tests := []struct {
+		name   string
+		update freshdesk.UpdateTicket
+		field  string
+	}{
+		{"status", freshdesk.UpdateTicket{Status: freshdesk.Ptr(9)}, "status"},
+		{"zero priority", freshdesk.UpdateTicket{Priority: freshdesk.Ptr(0)}, "priority"},
+		{"custom field", freshdesk.UpdateTicket{CustomFields: map[string]interface{}{"cf_tier": "Bronze"}}, "cf_tier"},
+	}
+	for _, test := range tests {
+		_, err := client.Tickets.Update(ticket.ID, test.update)
+		apiErr := freshdesk.APIError{}
+		if !freshdesk.IsValidationError(err) || !errors.As(err, &apiErr) || len(apiErr.Errors) != 1 || apiErr.Errors[0].Field != test.field {
+			t.Errorf("%s: err = %v, want a validation error on %s", test.name, err, test.field)
+		}
+		if test.field == "cf_tier" && !freshdesk.IsCustomFieldError(err) {
+			t.Errorf("%s: err = %v, want a custom field error", test.name, err)
+		}
+	}
+
+	// Rejected updates change nothing.
+	stored, _ := server.Ticket(ticket.ID)
+	if stored.Status != 2 || stored.Priority != 1 || stored.CustomFields["cf_tier"] != "Gold" {
+		t.Errorf("stored ticket changed: status %d, priority %d, custom fields %v", stored.Status, stored.Priority, stored.CustomFields)
+	}
+	if _, err := client.Tickets.Update(ticket.ID, freshdesk.UpdateTicket{CustomFields: map[string]interface{}{"cf_tier": "Silver"}}); err != nil {
+		t.Errorf("valid update: %v", err)
+	}
+}
+
+func TestDeleteAndRestoreTicket(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Printer", Status: 2, Priority: 1})
+	client := server.Client(nil)
+
+	if err := client.Tickets.Delete(ticket.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Tickets.View(ticket.ID); !freshdesk.IsNotFound(err) {
+		t.Errorf("View after Delete: err = %v, want not found", err)
+	}
+	if err := client.Tickets.Delete(ticket.ID); !freshdesk.IsNotFound(err) {
+		t.Errorf("second Delete: err = %v, want not found", err)
+	}
+
+	if err := client.Tickets.Restore(ticket.ID); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := client.Tickets.View(ticket.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Deleted || restored.Subject != "Printer" {
+		t.Errorf("restored ticket: deleted %v, subject %q", restored.Deleted, restored.Subject)
+	}
+	if err := client.Tickets.Restore(ticket.ID); !freshdesk.IsNotFound(err) {
+		t.Errorf("Restore of a ticket that is not deleted: err = %v, want not found", err)
+	}
+}
+
+func TestMergeIsNotRetried(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	primary := server.AddTicket(freshdesk.Ticket{Subject: "Primary", Status: 2, Priority: 1})
+	secondary := server.AddTicket(freshdesk.Ticket{Subject: "Duplicate", Status: 2, Priority: 1})
+	client := server.Client(&freshdesk.ClientOptions{RetryPolicy: fastRetries()})
+	merges := func() int {
+		count := 0
+		for _, req := range server.Requests() {
+			if req.Path == "/api/v2/tickets/merge" {
+				count++
+			}
+		}
+		return count
+	}
+
+	// The merge may have been applied before the 502 was returned.
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/tickets/merge", Status: http.StatusBadGateway, Count: 1})
+	if err := client.Tickets.Merge(primary.ID, []int64{secondary.ID}, freshdesk.MergeNotes{}); err == nil {
+		t.Fatal("merge succeeded despite the fault")
+	}
+	if got := merges(); got != 1 {
+		t.Errorf("merge took %d attempts, want 1", got)
+	}
+
+	// A 429 means it was not, and is retried.
+	server.InjectFault(freshdesktest.Fault{Path: "/api/v2/tickets/merge", Status: http.StatusTooManyRequests, Count: 1})
+	if err := client.Tickets.Merge(primary.ID, []int64{secondary.ID}, freshdesk.MergeNotes{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := merges(); got != 3 {
+		t.Errorf("merge took %d attempts after a 429, want 2", got-1)
+	}
+
+	// Other PUTs are still retried.
+	server.InjectFault(freshdesktest.Fault{Method: http.MethodPut, Status: http.StatusBadGateway, Count: 1})
+	if _, err := client.Tickets.Update(primary.ID, freshdesk.UpdateTicket{Priority: freshdesk.Ptr(2)}); err != nil {
+		t.Errorf("update was not retried: %v", err)
+	}
+}
+
+func TestListTicketsOptionsQuery(t *testing.T) {
+	transport := &recordingTransport{}
+	client := freshdesk.Init("acme", "key", &freshdesk.ClientOptions{Transport: transport})
+	_, err := client.Tickets.ListWithOptions(&freshdesk.ListTicketsOptions{
+		ListOptions:  freshdesk.ListOptions{PerPage: 50},
+		Filter:       freshdesk.TicketFilterWatching,
+		RequesterID:  12,
+		UpdatedSince: time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("CEST", 2*60*60)),
+		OrderBy:      "due_by",
+		OrderType:    "asc",
+		Include:      []string{"requester", "stats"},
+	}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "filter=watching&include=requester%2Cstats&order_by=due_by&order_type=asc&requester_id=12&updated_since=2024-01-02T01%3A04%3A05Z&per_page=50"
+	if len(transport.requests) != 1 || transport.requests[0].URL.RawQuery != want {
+		t.Fatalf("requests = %v, want one with the query %s", transport.requests, want)
+	}
+
+	// The filters are kept on every page.
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	for _, status := range []int{4, 2, 3} {
+		server.AddTicket(freshdesk.Ticket{Subject: "Listed", Status: status, CompanyID: 7})
+	}
+	server.AddTicket(freshdesk.Ticket{Subject: "Other company", Status: 2, CompanyID: 8})
+	client = server.Client(nil)
+	tickets, err := client.Tickets.ListWithOptions(&freshdesk.ListTicketsOptions{
+		ListOptions: freshdesk.ListOptions{PerPage: 2},
+		CompanyID:   7,
+		OrderBy:     "status",
+		OrderType:   "asc",
+	}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 3 || tickets[0].Status != 2 || tickets[1].Status != 3 || tickets[2].Status != 4 {
+		t.Errorf("got %d tickets: %+v", len(tickets), tickets)
+	}
+	pages := []string{}
+	for _, req := range server.Requests() {
+		pages = append(pages, req.Query.Encode())
+	}
+	wantPages := []string{
+		"company_id=7&order_by=status&order_type=asc&per_page=2",
+		"company_id=7&order_by=status&order_type=asc&page=2&per_page=2",
+	}
+	if !reflect.DeepEqual(pages, wantPages) {
+		t.Errorf("queries = %q, want %q", pages, wantPages)
+	}
+}
@@ -0,0 +1,304 @@
+package freshdesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type TimeEntryManager interface {
+	All(*ListTimeEntriesOptions) (TimeEntrySlice, error)
+	AllContext(context.Context, *ListTimeEntriesOptions) (TimeEntrySlice, error)
+	List(*ListTimeEntriesOptions) *Iterator[TimeEntry]
+	ForTicket(int64) (TimeEntrySlice, error)
+	ForTicketContext(context.Context, int64) (TimeEntrySlice, error)
+	ListForTicket(int64, *ListOptions) *Iterator[TimeEntry]
+	Create(int64, CreateTimeEntry) (TimeEntry, error)
+	CreateContext(context.Context, int64, CreateTimeEntry) (TimeEntry, error)
+	Update(int64, UpdateTimeEntry) (TimeEntry, error)
+	UpdateContext(context.Context, int64, UpdateTimeEntry) (TimeEntry, error)
+	ToggleTimer(int64) (TimeEntry, error)
+	ToggleTimerContext(context.Context, int64) (TimeEntry, error)
+	Delete(int64) error
+	DeleteContext(context.Context, int64) error
+}
+
+type timeEntryManager struct {
+	client *ApiClient
+}
+
+func newTimeEntryManager(client *ApiClient) timeEntryManager {
+	return timeEntryManager{
+		client,
+	}
+}
+
+type TimeEntry struct {
+	ID           int64         `json:"id"`
+	AgentID      int64         `json:"agent_id"`
+	TicketID     int64         `json:"ticket_id"`
+	CompanyID    int64         `json:"company_id"`
+	Billable     bool          `json:"billable"`
+	Note         string        `json:"note"`
+	TimerRunning bool          `json:"timer_running"`
+	TimeSpent    time.Duration `json:"-"`
+	ExecutedAt   *time.Time    `json:"executed_at"`
+	StartTime    *time.Time    `json:"start_time"`
+	CreatedAt    *time.Time    `json:"created_at"`
+	UpdatedAt    *time.Time    `json:"updated_at"`
+}
+
+// CreateTimeEntry is a new time entry. A timer is started when TimeSpent is
+// zero, unless TimerRunning says otherwise.
+type CreateTimeEntry struct {
+	AgentID int64 `json:"agent_id,omitempty"`
+	// Billable defaults to true.
+	Billable     *bool         `json:"billable,omitempty"`
+	Note         string        `json:"note,omitempty"`
+	TimerRunning *bool         `json:"timer_running,omitempty"`
+	TimeSpent    time.Duration `json:"-"`
+	ExecutedAt   *time.Time    `json:"executed_at,omitempty"`
+	StartTime    *time.Time    `json:"start_time,omitempty"`
+}
+
+// UpdateTimeEntry is a partial time entry update; only the fields that are
+// set are sent.
+type UpdateTimeEntry struct {
+	AgentID      *int64         `json:"agent_id,omitempty"`
+	Billable     *bool          `json:"billable,omitempty"`
+	Note         *string        `json:"note,omitempty"`
+	TimerRunning *bool          `json:"timer_running,omitempty"`
+	TimeSpent    *time.Duration `json:"-"`
+	ExecutedAt   *time.Time     `json:"executed_at,omitempty"`
+	StartTime    *time.Time     `json:"start_time,omitempty"`
+}
+
+// ListTimeEntriesOptions filters the time entries of the account. Zero fields
+// are left out of the request.
+type ListTimeEntriesOptions struct {
+	ListOptions
+	CompanyID      int64
+	AgentID        int64
+	ExecutedAfter  time.Time
+	ExecutedBefore time.Time
+	Billable       *bool
+}
+
+func (options *ListTimeEntriesOptions) apply(path string) string {
+	if options == nil {
+		return path
+	}
+	query := url.Values{}
+	if options.CompanyID != 0 {
+		query.Set("company_id", strconv.FormatInt(options.CompanyID, 10))
+	}
+	if options.AgentID != 0 {
+		query.Set("agent_id", strconv.FormatInt(options.AgentID, 10))
+	}
+	if !options.ExecutedAfter.IsZero() {
+		query.Set("executed_after", options.ExecutedAfter.UTC().Format(time.RFC3339))
+	}
+	if !options.ExecutedBefore.IsZero() {
+		query.Set("executed_before", options.ExecutedBefore.UTC().Format(time.RFC3339))
+	}
+	if options.Billable != nil {
+		query.Set("billable", strconv.FormatBool(*options.Billable))
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
+}
+
+// formatTimeSpent formats a duration as the API's "hh:mm", rounded to the
+// minute.
+func formatTimeSpent(d time.Duration) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func parseTimeSpent(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	var hours, minutes int64
+	if _, err := fmt.Sscanf(s, "%d:%d", &hours, &minutes); err != nil {
+		return 0, fmt.Errorf("invalid time_spent %q", s)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+func (entry TimeEntry) MarshalJSON() ([]byte, error) {
+	type timeEntry TimeEntry
+	return json.Marshal(struct {
+		timeEntry
+		TimeSpent string `json:"time_spent"`
+	}{timeEntry(entry), formatTimeSpent(entry.TimeSpent)})
+}
+
+func (entry *TimeEntry) UnmarshalJSON(data []byte) error {
+	type timeEntry TimeEntry
+	aux := struct {
+		*timeEntry
+		TimeSpent string `json:"time_spent"`
+	}{timeEntry: (*timeEntry)(entry)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	entry.TimeSpent, err = parseTimeSpent(aux.TimeSpent)
+	return err
+}
+
+func (entry CreateTimeEntry) MarshalJSON() ([]byte, error) {
+	type createTimeEntry CreateTimeEntry
+	timeSpent := ""
+	if entry.TimeSpent > 0 {
+		timeSpent = formatTimeSpent(entry.TimeSpent)
+	}
+	return json.Marshal(struct {
+		createTimeEntry
+		TimeSpent string `json:"time_spent,omitempty"`
+	}{createTimeEntry(entry), timeSpent})
+}
+
+func (entry UpdateTimeEntry) MarshalJSON() ([]byte, error) {
+	type updateTimeEntry UpdateTimeEntry
+	var timeSpent *string
+	if entry.TimeSpent != nil {
+		formatted := formatTimeSpent(*entry.TimeSpent)
+		timeSpent = &formatted
+	}
+	return json.Marshal(struct {
+		updateTimeEntry
+		TimeSpent *string `json:"time_spent,omitempty"`
+	}{updateTimeEntry(entry), timeSpent})
+}
+
+type TimeEntrySlice []TimeEntry
+
+func (s TimeEntrySlice) Len() int { return len(s) }
+
+func (s TimeEntrySlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s TimeEntrySlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s TimeEntrySlice) Print() {
+	for _, entry := range s {
+		fmt.Printf("%d: %s %s\n", entry.TicketID, entry.TimeSpent, entry.Note)
+	}
+}
+
+// Total returns the time spent over all entries.
+func (s TimeEntrySlice) Total() time.Duration {
+	var total time.Duration
+	for _, entry := range s {
+		total += entry.TimeSpent
+	}
+	return total
+}
+
+func (manager timeEntryManager) All(options *ListTimeEntriesOptions) (TimeEntrySlice, error) {
+	return manager.AllContext(context.Background(), options)
+}
+
+func (manager timeEntryManager) AllContext(ctx context.Context, options *ListTimeEntriesOptions) (TimeEntrySlice, error) {
+	output, err := manager.List(options).All(ctx)
+	if err != nil {
+		return TimeEntrySlice{}, err
+	}
+	return output, nil
+}
+
+func (manager timeEntryManager) List(options *ListTimeEntriesOptions) *Iterator[TimeEntry] {
+	path := endpoints.timeEntries.all
+	var listOptions *ListOptions
+	if options != nil {
+		path = options.apply(path)
+		listOptions = &options.ListOptions
+	}
+	return newLinkIterator[TimeEntry](manager.client, path, listOptions, "time_entries.list", nil)
+}
+
+func (manager timeEntryManager) ForTicket(ticketID int64) (TimeEntrySlice, error) {
+	return manager.ForTicketContext(context.Background(), ticketID)
+}
+
+func (manager timeEntryManager) ForTicketContext(ctx context.Context, ticketID int64) (TimeEntrySlice, error) {
+	output, err := manager.ListForTicket(ticketID, nil).All(ctx)
+	if err != nil {
+		return TimeEntrySlice{}, err
+	}
+	return output, nil
+}
+
+func (manager timeEntryManager) ListForTicket(ticketID int64, options *ListOptions) *Iterator[TimeEntry] {
+	return newLinkIterator[TimeEntry](manager.client, endpoints.tickets.timeEntries(ticketID), options, "tickets.time_entries.list", nil)
+}
+
+func (manager timeEntryManager) Create(ticketID int64, entry CreateTimeEntry) (TimeEntry, error) {
+	return manager.CreateContext(context.Background(), ticketID, entry)
+}
+
+func (manager timeEntryManager) CreateContext(ctx context.Context, ticketID int64, entry CreateTimeEntry) (TimeEntry, error) {
+	ctx = withOperation(ctx, "time_entries.create")
+	output := TimeEntry{}
+	jsonb, err := json.Marshal(entry)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.postJSON(ctx, endpoints.tickets.timeEntries(ticketID), jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	return output, nil
+}
+
+func (manager timeEntryManager) Update(id int64, entry UpdateTimeEntry) (TimeEntry, error) {
+	return manager.UpdateContext(context.Background(), id, entry)
+}
+
+func (manager timeEntryManager) UpdateContext(ctx context.Context, id int64, entry UpdateTimeEntry) (TimeEntry, error) {
+	ctx = withOperation(ctx, "time_entries.update")
+	output := TimeEntry{}
+	jsonb, err := json.Marshal(entry)
+	if err != nil {
+		return output, err
+	}
+	err = manager.client.put(ctx, endpoints.timeEntries.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	return output, nil
+}
+
+func (manager timeEntryManager) ToggleTimer(id int64) (TimeEntry, error) {
+	return manager.ToggleTimerContext(context.Background(), id)
+}
+
+// ToggleTimerContext starts the timer of the entry, or stops it and adds the
+// elapsed time to TimeSpent. It is not retried after a 5xx response or
+// network error, as a repeated toggle would undo the first; list the ticket's
+// time entries to find out whether the timer runs.
+func (manager timeEntryManager) ToggleTimerContext(ctx context.Context, id int64) (TimeEntry, error) {
+	ctx = withOperation(ctx, "time_entries.toggle_timer")
+	output := TimeEntry{}
+	err := manager.client.put(sendOnce(ctx), endpoints.timeEntries.toggleTimer(id), nil, &output, http.StatusOK)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	return output, nil
+}
+
+func (manager timeEntryManager) Delete(id int64) error {
+	return manager.DeleteContext(context.Background(), id)
+}
+
+func (manager timeEntryManager) DeleteContext(ctx context.Context, id int64) error {
+	ctx = withOperation(ctx, "time_entries.delete")
+	return manager.client.delete(ctx, endpoints.timeEntries.delete(id), http.StatusNoContent)
+}
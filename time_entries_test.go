@@ -0,0 +1,144 @@
+package freshdesk_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+)
+
+func TestTimeSpentJSON(t *testing.T) {
+	tests := []struct {
+		spent time.Duration
+		want  string
+	}{
+		{0, `"time_spent":"00:00"`},
+		{90 * time.Minute, `"time_spent":"01:30"`},
+		{29 * time.Second, `"time_spent":"00:00"`},
+		{90 * time.Second, `"time_spent":"00:02"`},
+		{25*time.Hour + 5*time.Minute, `"time_spent":"25:05"`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(freshdesk.TimeEntry{TimeSpent: test.spent})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), test.want) {
+			t.Errorf("TimeEntry with %v marshalled to %s, want %s", test.spent, data, test.want)
+		}
+	}
+
+	entry := freshdesk.TimeEntry{}
+	if err := json.Unmarshal([]byte(`{"id":3,"time_spent":"02:07","note":"n"}`), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.ID != 3 || entry.Note != "n" || entry.TimeSpent != 2*time.Hour+7*time.Minute {
+		t.Errorf("unmarshalled %+v", entry)
+	}
+	if err := json.Unmarshal([]byte(`{"time_spent":"soon"}`), &entry); err == nil {
+		t.Error("an invalid time_spent was accepted")
+	}
+
+	// Creating without a time leaves it out so the timer starts; updating
+	// to zero sends it.
+	data, _ := json.Marshal(freshdesk.CreateTimeEntry{Note: "timer"})
+	if strings.Contains(string(data), "time_spent") {
+		t.Errorf("CreateTimeEntry without TimeSpent marshalled to %s", data)
+	}
+	data, _ = json.Marshal(freshdesk.UpdateTimeEntry{})
+	if string(data) != `{}` {
+		t.Errorf("empty UpdateTimeEntry marshalled to %s", data)
+	}
+	data, _ = json.Marshal(freshdesk.UpdateTimeEntry{TimeSpent: freshdesk.Ptr(time.Duration(0))})
+	if string(data) != `{"time_spent":"00:00"}` {
+		t.Errorf("UpdateTimeEntry to zero marshalled to %s", data)
+	}
+}
+
+func TestTimeEntries(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	server.Now = func() time.Time { return now }
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Timed", Status: 2})
+	client := server.Client(nil)
+
+	logged, err := client.TimeEntries.Create(ticket.ID, freshdesk.CreateTimeEntry{Note: "call", TimeSpent: 90 * time.Minute, Billable: freshdesk.Ptr(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logged.TimeSpent != 90*time.Minute || logged.TimerRunning || logged.TicketID != ticket.ID {
+		t.Errorf("logged entry = %+v", logged)
+	}
+
+	timer, err := client.TimeEntries.Create(ticket.ID, freshdesk.CreateTimeEntry{Note: "research"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !timer.TimerRunning || timer.TimeSpent != 0 {
+		t.Errorf("timer entry = %+v, want a running timer", timer)
+	}
+	now = now.Add(20 * time.Minute)
+	if timer, err = client.TimeEntries.ToggleTimer(timer.ID); err != nil {
+		t.Fatal(err)
+	}
+	if timer.TimerRunning || timer.TimeSpent != 20*time.Minute {
+		t.Errorf("stopped timer = %+v, want 20m", timer)
+	}
+
+	updated, err := client.TimeEntries.Update(logged.ID, freshdesk.UpdateTimeEntry{TimeSpent: freshdesk.Ptr(45 * time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.TimeSpent != 45*time.Minute || updated.Note != "call" {
+		t.Errorf("updated entry = %+v", updated)
+	}
+
+	entries, err := client.TimeEntries.ForTicket(ticket.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total := entries.Total(); total != 65*time.Minute {
+		t.Errorf("Total = %v, want 1h5m", total)
+	}
+	billable, err := client.TimeEntries.AllContext(context.Background(), &freshdesk.ListTimeEntriesOptions{Billable: freshdesk.Ptr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(billable) != 1 || billable[0].ID != timer.ID {
+		t.Errorf("billable entries = %+v, want the timer", billable)
+	}
+}
+
+func TestToggleTimerIsNotRetried(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	ticket := server.AddTicket(freshdesk.Ticket{Subject: "Timed", Status: 2})
+	client := server.Client(&freshdesk.ClientOptions{RetryPolicy: fastRetries()})
+	timer, err := client.TimeEntries.Create(ticket.ID, freshdesk.CreateTimeEntry{Note: "research"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Repeating a toggle that went through would restart the timer.
+	path := "/api/v2/time_entries/" + strconv.FormatInt(timer.ID, 10) + "/toggle_timer"
+	server.InjectFault(freshdesktest.Fault{Path: path, Status: http.StatusBadGateway, Count: 1})
+	if _, err := client.TimeEntries.ToggleTimer(timer.ID); err == nil {
+		t.Fatal("toggle succeeded despite the fault")
+	}
+	toggles := 0
+	for _, req := range server.Requests() {
+		if req.Path == path {
+			toggles++
+		}
+	}
+	if toggles != 1 {
+		t.Errorf("toggle took %d attempts, want 1", toggles)
+	}
+}
@@ -1,6 +1,7 @@
 package freshdesk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,8 +13,17 @@ import (
 
 type UserManager interface {
 	All() (UserSlice, error)
+	AllContext(context.Context) (UserSlice, error)
+	List(*ListOptions) *Iterator[User]
 	Create(*User) (*User, error)
+	CreateContext(context.Context, *User) (*User, error)
+	Update(int64, *User) (*User, error)
+	UpdateContext(context.Context, int64, *User) (*User, error)
 	Search(querybuilder.Query) (UserResults, error)
+	SearchContext(context.Context, querybuilder.Query) (UserResults, error)
+	SearchIter(querybuilder.Query) *Iterator[User]
+	SearchAll(querybuilder.Query) (UserResults, error)
+	SearchAllContext(context.Context, querybuilder.Query) (UserResults, error)
 }
 
 type userManager struct {
@@ -26,10 +36,12 @@ func newUserManager(client *ApiClient) userManager {
 	}
 }
 
+// UserResults holds the contacts of one search page, or every match when
+// returned by SearchAll. Total and Truncated describe the whole search; when
+// Truncated is set, SearchAll finds the contacts Next cannot reach.
 type UserResults struct {
-	next    string
+	searchResults[User]
 	Results UserSlice
-	client  *ApiClient
 }
 
 type User struct {
@@ -75,48 +87,95 @@ func (s UserSlice) Print() {
 }
 
 func (manager userManager) All() (UserSlice, error) {
-	output := UserSlice{}
-	headers, err := manager.client.get(endpoints.contacts.all, &output)
+	return manager.AllContext(context.Background())
+}
+
+func (manager userManager) AllContext(ctx context.Context) (UserSlice, error) {
+	output, err := manager.List(nil).All(ctx)
 	if err != nil {
 		return UserSlice{}, err
 	}
-	for {
-		nextLink := manager.client.getNextLink(headers)
-		if nextLink == "" {
-			break
-		}
-		nextSlice := UserSlice{}
-		headers, err = manager.client.get(nextLink, &nextSlice)
-		if err != nil {
-			return UserSlice{}, err
-		}
-		output = append(output, nextSlice...)
-	}
 	return output, nil
 }
 
+func (manager userManager) List(options *ListOptions) *Iterator[User] {
+	return newLinkIterator[User](manager.client, endpoints.contacts.all, options, "contacts.list", nil)
+}
+
 func (manager userManager) Search(query querybuilder.Query) (UserResults, error) {
-	output := struct {
-		Slice UserSlice `json:"results,omitempty"`
-	}{}
-	headers, err := manager.client.get(endpoints.contacts.search(query.URLSafe()), &output)
+	return manager.SearchContext(context.Background(), query)
+}
+
+// SearchContext returns the first page of contacts matching query. Use Next
+// for the following pages.
+func (manager userManager) SearchContext(ctx context.Context, query querybuilder.Query) (UserResults, error) {
+	results := UserResults{
+		searchResults: newSearchResults[User](manager.client, "contacts", endpoints.contacts.search(query.URLSafe())),
+	}
+	return results.NextContext(ctx)
+}
+
+// SearchIter streams the contacts Search and Next would return, one at a
+// time.
+func (manager userManager) SearchIter(query querybuilder.Query) *Iterator[User] {
+	return newSearchIterator[User](manager.client, "contacts", endpoints.contacts.search(query.URLSafe()), nil)
+}
+
+func (manager userManager) SearchAll(query querybuilder.Query) (UserResults, error) {
+	return manager.SearchAllContext(context.Background(), query)
+}
+
+// SearchAllContext returns every contact matching query, splitting the search
+// into date windows when it matches more than the 300 contacts Freshdesk
+// returns for a single query.
+func (manager userManager) SearchAllContext(ctx context.Context, query querybuilder.Query) (UserResults, error) {
+	ctx = withOperation(ctx, "contacts.search")
+	output, truncated, err := searchPartitioned(ctx, manager.client, endpoints.contacts.search, query, func(user User) int64 {
+		return user.ID
+	})
 	if err != nil {
 		return UserResults{}, err
 	}
 	return UserResults{
-		Results: output.Slice,
-		client:  manager.client,
-		next:    manager.client.getNextLink(headers),
+		searchResults: searchResults[User]{
+			records:   "contacts",
+			Total:     len(output),
+			Truncated: truncated,
+			client:    manager.client,
+		},
+		Results: output,
+	}, nil
+}
+
+// Next returns the page of contacts after results. Results from SearchAll
+// have no next page.
+func (results UserResults) Next() (UserResults, error) {
+	return results.NextContext(context.Background())
+}
+
+func (results UserResults) NextContext(ctx context.Context) (UserResults, error) {
+	output, next, err := results.next(ctx)
+	if err != nil {
+		return UserResults{}, err
+	}
+	return UserResults{
+		searchResults: next,
+		Results:       output,
 	}, nil
 }
 
 func (manager userManager) Create(user *User) (*User, error) {
+	return manager.CreateContext(context.Background(), user)
+}
+
+func (manager userManager) CreateContext(ctx context.Context, user *User) (*User, error) {
+	ctx = withOperation(ctx, "contacts.create")
 	output := &User{}
 	jsonb, err := json.Marshal(user)
 	if err != nil {
 		return output, err
 	}
-	err = manager.client.postJSON(endpoints.contacts.create, jsonb, &output, http.StatusCreated)
+	err = manager.client.postJSON(ctx, endpoints.contacts.create, jsonb, &output, http.StatusCreated)
 	if err != nil {
 		return &User{}, err
 	}
@@ -124,12 +183,17 @@ func (manager userManager) Create(user *User) (*User, error) {
 }
 
 func (manager userManager) Update(id int64, user *User) (*User, error) {
+	return manager.UpdateContext(context.Background(), id, user)
+}
+
+func (manager userManager) UpdateContext(ctx context.Context, id int64, user *User) (*User, error) {
+	ctx = withOperation(ctx, "contacts.update")
 	output := &User{}
 	jsonb, err := json.Marshal(user)
 	if err != nil {
 		return output, err
 	}
-	err = manager.client.put(endpoints.contacts.update(id), jsonb, &output, http.StatusOK)
+	err = manager.client.put(ctx, endpoints.contacts.update(id), jsonb, &output, http.StatusOK)
 	if err != nil {
 		return &User{}, err
 	}
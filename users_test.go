@@ -0,0 +1,78 @@
+package freshdesk_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	freshdesk "github.com/nextlinktechnology/go-freshdesk"
+	"github.com/nextlinktechnology/go-freshdesk/freshdesktest"
+	"github.com/nextlinktechnology/go-freshdesk/querybuilder"
+)
+
+func TestContactSearchStopsAfterPageTen(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.Now = spreadClock(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 6*time.Hour)
+	for i := 0; i < 320; i++ {
+		server.AddContact(freshdesk.User{Name: fmt.Sprintf("Contact %d", i), Email: fmt.Sprintf("c%d@example.com", i), Language: "fr"})
+	}
+	client := server.Client(nil)
+
+	results, err := client.Contacts.Search(querybuilder.Parameter("language").Is("fr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 320 || !results.Truncated {
+		t.Errorf("Total = %d, Truncated = %v, want 320 and true", results.Total, results.Truncated)
+	}
+	pages, seen := 1, len(results.Results)
+	for {
+		results, err = results.Next()
+		if err != nil {
+			break
+		}
+		pages++
+		seen += len(results.Results)
+	}
+	if pages != 10 || seen != 300 {
+		t.Errorf("got %d contacts on %d pages, want 300 on 10", seen, pages)
+	}
+
+	all, err := client.Contacts.SearchAll(querybuilder.Parameter("language").Is("fr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Results) != 320 || all.Total != 320 || all.Truncated {
+		t.Errorf("SearchAll got %d results, Total = %d, Truncated = %v", len(all.Results), all.Total, all.Truncated)
+	}
+}
+
+func TestContactSearchSinglePage(t *testing.T) {
+	server := freshdesktest.NewServer()
+	defer server.Close()
+	server.AddContact(freshdesk.User{Name: "Ada", Email: "ada@example.com", Language: "en"})
+	server.AddContact(freshdesk.User{Name: "Grace", Email: "grace@example.com", Language: "de"})
+	client := server.Client(nil)
+
+	results, err := client.Contacts.Search(querybuilder.Parameter("language").Is("en"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 1 || results.Results[0].Name != "Ada" || results.Total != 1 || results.Truncated {
+		t.Errorf("got %+v", results)
+	}
+	if _, err := results.Next(); err == nil {
+		t.Error("Next after the last page returned no error")
+	}
+}
+
+// spreadClock returns a clock for freshdesktest.Server.Now that advances by
+// step on every call, so that records get distinct timestamps.
+func spreadClock(start time.Time, step time.Duration) func() time.Time {
+	now := start
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}